@@ -1,38 +1,37 @@
-package main
+package puzzle1
 
 import (
-	"bufio"
+	_ "embed"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+
+	aocutilites "AOC2025/aocutilities"
 )
 
-// Read the input into a slice of strings so it can be used by both part1 and part2
-func readInput(inputFile string) ([]string, error) {
+type solver struct{}
 
-	output := []string{}
-	file, err := os.Open(inputFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return output, err
-	}
-	defer file.Close()
+//go:embed testdata/example.txt
+var example string
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		// Process each line here
-		output = append(output, line)
-	}
+func init() {
+	aocutilites.Register(1, solver{})
+	aocutilites.RegisterInput(1, "example", example)
+}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-	}
+func (solver) Parse(r io.Reader) ([]string, error) {
+	return aocutilites.ReadLines(r)
+}
+
+func (solver) Part1(input []string) (int, error) {
+	return solution(input, false)
+}
 
-	return output, nil
+func (solver) Part2(input []string) (int, error) {
+	return solution(input, true)
 }
 
-func solution(input []string, part2 bool) {
+func solution(input []string, part2 bool) (int, error) {
 
 	position := 50
 	zeroCount := 0
@@ -43,8 +42,7 @@ func solution(input []string, part2 bool) {
 		distance, err := strconv.Atoi(line[1:])
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error converting string to int: %v\n", err)
-			os.Exit(1)
+			return zeroCount, fmt.Errorf("converting string to int: %w", err)
 		}
 
 		if direction == "R" {
@@ -82,20 +80,5 @@ func solution(input []string, part2 bool) {
 		}
 	}
 
-	fmt.Printf("Final Position: %d, Zero Count: %d\n", position, zeroCount)
-
-}
-
-func main() {
-
-	input, err := readInput("input.txt")
-	if err != nil {
-		os.Exit(1)
-	}
-
-	// Part 1
-	solution(input, false)
-
-	// Part 2
-	solution(input, true)
+	return zeroCount, nil
 }
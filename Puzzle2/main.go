@@ -1,75 +1,79 @@
-package main
+package puzzle2
 
 import (
-	"bufio"
+	_ "embed"
 	"fmt"
-	"math"
-	"os"
-	"strconv"
-	"strings"
+	"io"
+
+	aocutilites "AOC2025/aocutilities"
 )
 
-func readInput(inputFile string) (string, error) {
+type solver struct{}
+
+//go:embed testdata/example.txt
+var example string
 
-	output := ""
+func init() {
+	aocutilites.Register(2, solver{})
+	aocutilites.RegisterInput(2, "example", example)
+}
+
+// IDRange is one sequence of IDs, start and end included
+type IDRange struct {
+	Start int
+	End   int
+}
 
-	file, err := os.Open(inputFile)
+// The input is one long line of comma separated sequences
+func (solver) Parse(r io.Reader) ([]IDRange, error) {
+	records, err := aocutilites.ReadRecords(r, ",")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return output, err
+		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		// Process each line here
-		output = output + line
+	parser, err := aocutilites.NewParser[IDRange]("{Start}-{End}")
+	if err != nil {
+		return nil, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+	sequences := make([]IDRange, len(records))
+	for i, record := range records {
+		if sequences[i], err = parser.Parse(record); err != nil {
+			return nil, fmt.Errorf("sequence %d: %w", i+1, err)
+		}
 	}
+	return sequences, nil
+}
+
+func (solver) Part1(sequences []IDRange) (int, error) {
+	return part1(sequences)
+}
 
-	return output, nil
+func (solver) Part2(sequences []IDRange) (int, error) {
+	return part2(sequences)
 }
 
-func part1(input string) (int, error) {
+func part1(sequences []IDRange) (int, error) {
 
 	result := 0
 	invalidNums := []int{}
-	// split the input into individual sequences around the commmas
-	sequences := strings.Split(input, ",")
 
 	for _, seq := range sequences {
-		// split the sequences into start and end around the hyphen
-		sL := strings.Split(seq, "-")[0]
-		sU := strings.Split(seq, "-")[1]
-
-		L, err := strconv.Atoi(sL)
-
-		if err != nil {
-			return result, err
-		}
-		U, err := strconv.Atoi(sU)
-
-		if err != nil {
-			return result, err
-		}
+		L, U := seq.Start, seq.End
 
 		// Ignore sequences where both L and U have an odd number of digits
 		if !((countDigits(L)%2 != 0) && (countDigits(U)%2 != 0)) {
 
-			// increment L until it has an even number of digits
-			for (countDigits(L)%2 != 0) && L <= U {
-				L++
+			// move L up to the first number with an even number of digits
+			if countDigits(L)%2 != 0 {
+				L = aocutilites.PowersOfTen[countDigits(L)]
 			}
 
 			patternLen := countDigits(L) / 2
-			multiplier := int(math.Pow(10, float64(patternLen))) + 1
+			multiplier := aocutilites.PowersOfTen[patternLen] + 1
 
-			P1 := (L + multiplier - 1) / multiplier
-			P2 := (U + multiplier - 1) / multiplier
+			P1 := ceilDiv(L, multiplier)
+			P2 := U / multiplier
 
 			for P := P1; P <= P2; P++ {
 				invalidNum := P * multiplier
@@ -88,37 +92,27 @@ func part1(input string) (int, error) {
 	return result, nil
 }
 
-func part2(input string) (int, error) {
+func part2(sequences []IDRange) (int, error) {
 
 	result := 0
-	invalidNums := map[int]bool{}
-
-	// split the input into individual sequences around the commmas
-	sequences := strings.Split(input, ",")
+	// A number can repeat more than one way, e.g. 1111 is 1 and 11, so
+	// collect them in a set
+	invalidNums := aocutilites.Set[int]{}
 
 	for _, seq := range sequences {
-		// split the sequeneces into start and end around the hyphen
-		sL := strings.Split(seq, "-")[0]
-		sU := strings.Split(seq, "-")[1]
-
-		L, err := strconv.Atoi(sL)
-
-		if err != nil {
-			return result, err
-		}
-		U, err := strconv.Atoi(sU)
-
-		if err != nil {
-			return result, err
-		}
+		L, U := seq.Start, seq.End
 
 		LD := countDigits(L)
 		UD := countDigits(U)
 
 		for d := LD; d <= UD; d++ {
 
-			lower := max(L, (int(math.Pow(10, float64(d-1)))))
-			upper := min(U, (int(math.Pow(10, float64(d))) - 1))
+			lower := max(L, aocutilites.PowersOfTen[d-1])
+			upper := U
+			// 10^19 doesn't fit in an int, but then neither does U
+			if p, ok := aocutilites.Pow10(d); ok {
+				upper = min(U, p-1)
+			}
 
 			for div := 1; div <= d/2; div++ {
 
@@ -126,17 +120,17 @@ func part2(input string) (int, error) {
 					continue
 				}
 
-				multiplier := (int(math.Pow(10, float64(d))) - 1) / (int(math.Pow(10, float64(div))) - 1)
+				multiplier := repeatMultiplier(d, div)
 
-				P1 := (lower + multiplier - 1) / multiplier
-				P2 := (upper + multiplier - 1) / multiplier
+				P1 := ceilDiv(lower, multiplier)
+				P2 := upper / multiplier
 
 				for P := P1; P <= P2; P++ {
 					invalidNum := P * multiplier
 
 					if (invalidNum >= L) && (invalidNum <= U) {
 
-						invalidNums[invalidNum] = true
+						invalidNums.Add(invalidNum)
 					}
 
 				}
@@ -152,40 +146,26 @@ func part2(input string) (int, error) {
 }
 
 func countDigits(num int) int {
-	if num == 0 {
-		return 1
-	}
-	count := 0
-	for num != 0 {
-		num /= 10
-		count++
-	}
-	return count
+	return aocutilites.CountDigits(num)
 }
 
-func main() {
-	input, err := readInput("input.txt")
-
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		os.Exit(1)
+// repeatMultiplier turns a pattern of div digits into that pattern repeated
+// to fill d digits, e.g. 12 * 10101 = 121212. It's (10^d - 1) / (10^div - 1)
+// but built up a term at a time so 10^d never has to fit in an int.
+func repeatMultiplier(d, div int) int {
+	multiplier := 0
+	for i := 0; i < d; i += div {
+		multiplier += aocutilites.PowersOfTen[i]
 	}
+	return multiplier
+}
 
-	result, err := part1(input)
-
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error in part1: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 1 Result: %d\n", result)
-
-	result, err = part2(input)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error in part2: %v\n", err)
-		os.Exit(1)
+// ceilDiv is a / b rounded up, for positive numbers, without the overflow
+// of (a + b - 1) / b.
+func ceilDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 {
+		q++
 	}
-
-	fmt.Printf("Part 2 Result: %d\n", result)
-
+	return q
 }
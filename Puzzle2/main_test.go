@@ -0,0 +1,35 @@
+package puzzle2
+
+import (
+	"strings"
+	"testing"
+
+	"AOC2025/aocutilities/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part1 int
+		part2 int
+	}{
+		{"example", "testdata/example.txt", 1227775554, 4174379265},
+		{"18 digit ids", "testdata/large-even.txt", 999999999999999999, 999999999999999999},
+		{"19 digit ids", "testdata/large.txt", 0, 8888888888888888888},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aoctest.Check(t, solver{}, tt.input, tt.part1, tt.part2)
+		})
+	}
+}
+
+func TestBadInput(t *testing.T) {
+	_, err := solver{}.Parse(strings.NewReader("11-22,95x115,998-1012\n"))
+	want := `sequence 2: column 1: expected {Start} then "-" in "95x115"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("Parse error = %v, want %q", err, want)
+	}
+}
@@ -1,26 +1,45 @@
-package main
+package puzzle3
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	_ "embed"
+	"io"
 	"strconv"
+
+	aocutilites "AOC2025/aocutilities"
+	"AOC2025/aocutilities/seq"
 )
 
-func readInput(inputFile string) ([][]int, error) {
+type solver struct{}
+
+//go:embed testdata/example.txt
+var example string
+
+func init() {
+	aocutilites.Register(3, solver{})
+	aocutilites.RegisterInput(3, "example", example)
+}
+
+func (solver) Parse(r io.Reader) ([][]int, error) {
+	return readInput(r)
+}
+
+func (solver) Part1(input [][]int) (int, error) {
+	return part1(input)
+}
+
+func (solver) Part2(input [][]int) (int, error) {
+	return part2(input)
+}
+
+func readInput(r io.Reader) ([][]int, error) {
 	output := [][]int{}
 
-	file, err := os.Open(inputFile)
+	lines, err := aocutilites.ReadLines(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
 		return output, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		// Process each line here
+	for _, line := range lines {
 		nums := []int{}
 
 		for i := 0; i < len(line); i++ {
@@ -36,47 +55,41 @@ func readInput(inputFile string) ([][]int, error) {
 
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-	}
-
 	return output, nil
 }
 
 func part1(input [][]int) (int, error) {
-	result := 0
+	result := aocutilites.NewInt(0)
 
-	for _, bank := range input {
+	for lineNo, bank := range input {
 		// fmt.Printf("Battery Bank: %v\n", bank)
-		bankMax := 0
+		bankMax := aocutilites.NewInt(0)
 		highest := 0
-		for battIndex, battJoltage := range bank {
-			if battIndex < len(bank)-1 {
-				if battJoltage > highest {
-					highest = battJoltage
-				}
-
-				jolts := []int{highest, bank[battIndex+1]}
-				if makeJolts(jolts) > bankMax {
-					bankMax = makeJolts(jolts)
-				}
+		// Pair the best battery so far with each one after it
+		for pair := range seq.Windows(bank, 2) {
+			if pair[0] > highest {
+				highest = pair[0]
 			}
 
+			jolts := []int{highest, pair[1]}
+			if j := makeJolts(jolts); j.Cmp(bankMax) > 0 {
+				bankMax = j
+			}
 		}
 
-		result = result + bankMax
+		result = result.Add(bankMax).At(lineNo+1, 0)
 
 	}
 
-	return result, nil
+	return result.Result()
 }
 
 // Part 1 approach couldn't be extended to part 2. Rethink the naive implementation
 
 func part2(input [][]int) (int, error) {
-	result := 0
+	result := aocutilites.NewInt(0)
 
-	for _, bank := range input {
+	for lineNo, bank := range input {
 
 		enabled := []int{}
 		available := len(bank) - 12
@@ -101,42 +114,16 @@ func part2(input [][]int) (int, error) {
 			fmt.Printf("jolts: %v\n\n", makeJolts(enabled))
 		*/
 
-		result = result + makeJolts(enabled)
+		result = result.Add(makeJolts(enabled)).At(lineNo+1, 0)
 	}
 
-	return result, nil
+	return result.Result()
 }
 
-func makeJolts(digits []int) int {
-	result := 0
+func makeJolts(digits []int) aocutilites.Int {
+	result := aocutilites.NewInt(0)
 	for _, d := range digits {
-		result = result*10 + d
+		result = result.MulInt(10).AddInt(d)
 	}
 	return result
 }
-
-func main() {
-
-	input, err := readInput("input.txt")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		os.Exit(1)
-	}
-
-	result, err := part1(input)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error in part1: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 1 - Total output joltage: %d\n\n", result)
-
-	result, err = part2(input)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error in part2: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 2 - Total output joltage: %d\n", result)
-
-}
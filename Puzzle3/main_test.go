@@ -0,0 +1,24 @@
+package puzzle3
+
+import (
+	"testing"
+
+	"AOC2025/aocutilities/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part1 int
+		part2 int
+	}{
+		{"example", "testdata/example.txt", 357, 3121910778619},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aoctest.Check(t, solver{}, tt.input, tt.part1, tt.part2)
+		})
+	}
+}
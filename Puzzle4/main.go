@@ -1,46 +1,76 @@
-package main
+package puzzle4
 
 import (
-	"bufio"
+	_ "embed"
 	"fmt"
-	"os"
+	"io"
+
+	aocutilites "AOC2025/aocutilities"
 )
 
-type Point struct {
-	row, col int
+type Grid = aocutilites.PointSet
+
+type solver struct{}
+
+//go:embed testdata/example.txt
+var example string
+
+func init() {
+	aocutilites.Register(4, solver{})
+	aocutilites.RegisterInput(4, "example", example)
 }
 
-type Grid map[Point]bool
+func (solver) Parse(r io.Reader) (Grid, error) {
+	return readInput(r)
+}
 
-// Read the input, make it useful for both parts
-func readInput(inputFile string) (Grid, error) {
+func (solver) Part1(grid Grid) (int, error) {
+	return part1(grid), nil
+}
 
-	output := make(map[Point]bool)
-	file, err := os.Open(inputFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return output, err
-	}
-	defer file.Close()
-
-	row := 0
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		row++
-
-		for col, char := range line {
-			if char == '@' {
-				output[Point{row: row, col: col + 1}] = true
-			}
+// part2 removes rolls from the grid as it goes, so give it a copy.
+func (solver) Part2(grid Grid) (int, error) {
+	return part2(grid.Clone(), 0), nil
+}
+
+// Visualise prints the rolls after each removal round. Part 1 is just the
+// first round.
+func (solver) Visualise(grid Grid, part int) error {
+	grid = grid.Clone()
+	lo, hi, _ := grid.Bounds()
+
+	fmt.Print(grid.RenderBox(lo, hi, '@'))
+	for round := 1; ; round++ {
+		removed := removable(grid)
+		if len(removed) == 0 {
+			break
+		}
+		for _, loc := range removed {
+			grid.Remove(loc)
 		}
 
-		if err := scanner.Err(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		fmt.Printf("\nRound %d: removed %d rolls, %d left\n", round, len(removed), grid.Len())
+		fmt.Print(grid.RenderBox(lo, hi, '@'))
+
+		if part == 1 {
+			break
 		}
 	}
 
-	return output, nil
+	return nil
+}
+
+// Read the input, make it useful for both parts
+func readInput(r io.Reader) (Grid, error) {
+
+	lines, err := aocutilites.ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The trick is to only add locations for the rolls (@).
+	// Learnt this in previous years.
+	return aocutilites.ParsePointSet(lines, '@'), nil
 }
 
 // Solve part 1
@@ -48,7 +78,7 @@ func part1(grid Grid) int {
 
 	result := 0
 	for gridLoc := range grid {
-		if countNeighbours(grid, gridLoc) < 4 {
+		if grid.CountNeighbours(gridLoc, aocutilites.Directions8) < 4 {
 			result++
 		}
 	}
@@ -59,58 +89,28 @@ func part1(grid Grid) int {
 // Solve part 2 - Do it recursively
 func part2(grid Grid, total int) int {
 
-	removable := []Point{}
-	for gridLoc := range grid {
-		neighbourCount := countNeighbours(grid, gridLoc)
-
-		if neighbourCount < 4 {
-			removable = append(removable, gridLoc)
-		}
-
-	}
+	removed := removable(grid)
 
 	// exit the recursion
-	if len(removable) == 0 {
+	if len(removed) == 0 {
 		return total
 	}
 
-	for _, loc := range removable {
-		delete(grid, loc)
+	for _, loc := range removed {
+		grid.Remove(loc)
 		total++
 	}
 
 	return part2(grid, total)
 }
 
-// The trick is to only add locations for the rolls (@).
-// Learnt this in previous years.
-func countNeighbours(grid Grid, gridLoc Point) int {
-	neighbourCount := 0
-
-	for gridRow := -1; gridRow <= 1; gridRow++ {
-		for gridCol := -1; gridCol <= 1; gridCol++ {
-			if gridRow == 0 && gridCol == 0 {
-				continue
-			}
-
-			if grid[Point{gridLoc.row + gridRow, gridLoc.col + gridCol}] {
-				neighbourCount++
-			}
+// Rolls with fewer than 4 neighbours can be removed
+func removable(grid Grid) []aocutilites.Point {
+	removable := []aocutilites.Point{}
+	for gridLoc := range grid {
+		if grid.CountNeighbours(gridLoc, aocutilites.Directions8) < 4 {
+			removable = append(removable, gridLoc)
 		}
 	}
-
-	return neighbourCount
-}
-
-func main() {
-	grid, err := readInput("input.txt")
-	if err != nil {
-		os.Exit(1)
-	}
-
-	result := part1(grid)
-	fmt.Printf("Part 1 - Total accessible rolls:\t%d\n", result)
-
-	result = part2(grid, 0)
-	fmt.Printf("Part 2 - Total removable rolls:\t\t%d\n", result)
+	return removable
 }
@@ -1,118 +1,99 @@
-package main
+package puzzle5
 
 import (
-	"bufio"
+	_ "embed"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
-	"strings"
+
+	aocutilites "AOC2025/aocutilities"
 )
 
 type IngredientRange struct {
-	start int
-	end   int
+	Start int
+	End   int
 }
 
 type IngredientRanges []IngredientRange
 type IngredientList []int
 
-func readInput(inputFile string) (IngredientRanges, IngredientList, error) {
-	iRange := IngredientRanges{}
-	iList := IngredientList{}
-
-	file, err := os.Open(inputFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return iRange, iList, err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-
-	rangeSection := true
-	for scanner.Scan() {
-		line := scanner.Text()
+type Ingredients struct {
+	fresh *aocutilites.IntervalSet
+	list  IngredientList
+}
 
-		if line == "" {
-			rangeSection = false
-			continue
-		}
+type solver struct{}
 
-		if rangeSection {
-			// Process ranges
-			sRangeStart := strings.Split(line, "-")[0]
-			sRangeEnd := strings.Split(line, "-")[1]
+//go:embed testdata/example.txt
+var example string
 
-			rangeStart, err := strconv.Atoi(sRangeStart)
+func init() {
+	aocutilites.Register(5, solver{})
+	aocutilites.RegisterInput(5, "example", example)
+}
 
-			if err != nil {
-				return iRange, iList, err
-			}
+func (solver) Parse(r io.Reader) (Ingredients, error) {
+	iRange, iList, err := readInput(r)
+	if err != nil {
+		return Ingredients{}, err
+	}
 
-			rangeEnd, err := strconv.Atoi(sRangeEnd)
+	// Overlapping ranges are merged as they go in
+	fresh := &aocutilites.IntervalSet{}
+	for _, r := range iRange {
+		fresh.Insert(aocutilites.Interval{Start: r.Start, End: r.End})
+	}
 
-			if err != nil {
-				return iRange, iList, err
-			}
+	return Ingredients{fresh: fresh, list: iList}, nil
+}
 
-			iRange = append(iRange, IngredientRange{start: rangeStart, end: rangeEnd})
+func (solver) Part1(input Ingredients) (int, error) {
+	return part1(input.fresh, input.list)
+}
 
-		} else {
-			// Process ingredient list
-			ingredient, err := strconv.Atoi(line)
+func (solver) Part2(input Ingredients) (int, error) {
+	return part2(input.fresh)
+}
 
-			if err != nil {
-				return iRange, iList, err
-			}
-			iList = append(iList, ingredient)
-		}
+func readInput(r io.Reader) (IngredientRanges, IngredientList, error) {
+	iRange := IngredientRanges{}
+	iList := IngredientList{}
 
+	// The ranges come first, then a blank line, then the ingredient list
+	sections, err := aocutilites.ReadSections(r)
+	if err != nil {
+		return iRange, iList, err
 	}
-
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+	if len(sections) != 2 {
+		return iRange, iList, fmt.Errorf("expected 2 sections in the input, found %d", len(sections))
 	}
 
-	// Sort iRange by start value
-	for i := 0; i < len(iRange)-1; i++ {
-		for j := i + 1; j < len(iRange); j++ {
-			if iRange[i].start > iRange[j].start {
-				// Swap
-				iRange[i], iRange[j] = iRange[j], iRange[i]
-			}
-		}
+	// The ranges are the first lines, so the line numbers in any error
+	// are right
+	iRange, err = aocutilites.ParseLines[IngredientRange](sections[0], "{Start}-{End}")
+	if err != nil {
+		return iRange, iList, err
 	}
 
-	//Sort iList
-	for i := 0; i < len(iList)-1; i++ {
-		for j := i + 1; j < len(iList); j++ {
-			if iList[i] > iList[j] {
-				// Swap
-				iList[i], iList[j] = iList[j], iList[i]
-			}
+	for _, line := range sections[1] {
+		// Process ingredient list
+		ingredient, err := strconv.Atoi(line)
+
+		if err != nil {
+			return iRange, iList, err
 		}
+		iList = append(iList, ingredient)
 	}
 
 	return iRange, iList, nil
 }
 
-func part1(ranges IngredientRanges, ingredients IngredientList) (int, error) {
+func part1(fresh *aocutilites.IntervalSet, ingredients IngredientList) (int, error) {
 
 	freshIngredients := 0
 
-	// First normalise the ranges
-	newRanges := normaliseRanges(ranges)
-
 	for _, ingredient := range ingredients {
-		inRange := false
-		for _, r := range newRanges {
-			if ingredient >= r.start && ingredient <= r.end {
-				inRange = true
-				break
-			}
-		}
-
-		if inRange {
+		if fresh.Contains(ingredient) {
 			freshIngredients++
 		}
 	}
@@ -120,79 +101,6 @@ func part1(ranges IngredientRanges, ingredients IngredientList) (int, error) {
 	return freshIngredients, nil
 }
 
-func part2(iRanges IngredientRanges) (int, error) {
-	freshIngredients := 0
-
-	// First normalise the ranges
-	newRanges := normaliseRanges(iRanges)
-
-	for _, freshrange := range newRanges {
-
-		//fmt.Printf("Range start: %d Range end: %d\n", freshrange.start, freshrange.end)
-		freshIngredients += (freshrange.end - freshrange.start + 1)
-	}
-
-	return freshIngredients, nil
-
-}
-
-func normaliseRanges(ranges IngredientRanges) IngredientRanges {
-
-	/*
-		3-5
-		10-14
-		16-20
-		12-18
-
-		Normalizes to:
-		3-5
-		10-20
-
-	*/
-
-	outputRanges := IngredientRanges{}
-	outputRanges = append(outputRanges, ranges[0])
-
-	for rangeNum, r := range ranges {
-		// ignore first range as already added
-		if rangeNum == 0 {
-			continue
-		}
-		lastIdx := len(outputRanges) - 1
-
-		if r.start <= outputRanges[lastIdx].end {
-			outputRanges[lastIdx].end = max(outputRanges[lastIdx].end, r.end)
-		} else {
-			outputRanges = append(outputRanges, r)
-		}
-
-	}
-
-	return outputRanges
-
-}
-
-func main() {
-	iRange, iList, err := readInput("input.txt")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		os.Exit(1)
-	}
-
-	result, err := part1(iRange, iList)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error in part1: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 1 - Fresh ingredient count: %d\n", result)
-
-	result, err = part2(iRange)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error in part1: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 2 - Fresh ingredient count: %d\n", result)
-
+func part2(fresh *aocutilites.IntervalSet) (int, error) {
+	return fresh.TotalLength(), nil
 }
@@ -1,255 +1,136 @@
-package main
+package puzzle6
 
 import (
-	"bufio"
+	_ "embed"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+	"strings"
 
 	aocutilites "AOC2025/aocutilities"
 )
 
-func readInput(inputFile string) ([][]int, []string, error) {
-	file, err := os.Open(inputFile)
+// Worksheet is the list of problems, each one block of columns on the sheet.
+type Worksheet []problem
 
-	operandsOutput := [][]int{}
-	operationsOutput := []string{}
-
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return operandsOutput, operationsOutput, err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		tmpArr := aocutilites.SplitWords(line)
-		numArr := []int{}
-
-		for _, word := range tmpArr {
-
-			if word != "+" && word != "-" && word != "*" && word != "/" {
-				num, err := strconv.Atoi(word)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error converting string to int: %v\n", err)
-					return operandsOutput, operationsOutput, err
-				}
-				numArr = append(numArr, num)
-			} else {
-				operationsOutput = append(operationsOutput, word)
-			}
-		}
-		if len(numArr) > 0 {
-			operandsOutput = append(operandsOutput, numArr)
-		}
-
-	}
-
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-	}
-
-	return operandsOutput, operationsOutput, err
+// problem is one block of the worksheet read both ways: rows holds a number
+// per line for part 1, cols a number per column for part 2.
+type problem struct {
+	op   string
+	rows []operand
+	cols []operand
 }
 
-func readInputPart2(inputFile string) ([][]string, error) {
-	output := [][]string{}
-	file, err := os.Open(inputFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return output, err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		// read the line into an array of characters including spaces
-		charArr := []string{}
-		for _, char := range line {
-			charArr = append(charArr, string(char))
-		}
-		output = append(output, charArr)
+type solver struct{}
 
-	}
+//go:embed testdata/example.txt
+var example string
 
-	return output, nil
+func init() {
+	aocutilites.Register(6, solver{})
+	aocutilites.RegisterInput(6, "example", example)
 }
 
-func part1(operands [][]int, operations []string) int {
-
-	total := 0
-	stackTotal := 0
-
-	for currentCol, op := range operations {
-
-		calcStack := aocutilites.Stack[int]{}
-
-		for _, row := range operands {
-			calcStack.Push(row[currentCol])
-		}
-
-		stackTotal = stackMaths(op, calcStack)
-
-		total += stackTotal
-		stackTotal = 0
-
-	}
-
-	return total
-}
-
-// Ok this code is a mess! Cobbled together iteratively to get to the answer.
-// But it does the job.
-func part2(inputFile string) int {
-	total := 0
-
-	output, err := readInputPart2(inputFile)
+func (solver) Parse(r io.Reader) (Worksheet, error) {
+	lines, err := aocutilites.ReadLines(r)
 	if err != nil {
-		return total
+		return nil, err
 	}
 
-	ops := output[len(output)-1]
-	operations := []string{}
-
-	for _, op := range ops {
-		if op != " " {
-			operations = append(operations, op)
-		}
+	blocks, err := aocutilites.ParseWorksheet(lines)
+	if err != nil {
+		return nil, err
 	}
 
-	output = output[:len(output)-1]
-	rows := len(output) - 1
-	cols := len(output[0])
+	sheet := make(Worksheet, len(blocks))
+	for i, b := range blocks {
+		p := problem{op: b.Op}
 
-	// Temp Output to visualize
-	/*
-		for _, row := range output {
-			fmt.Printf("%v\n", row)
-		}
-		fmt.Printf("%v\n\n", operations)
-	*/
-	opIndex := 0
-	op := ""
-
-	prevWasBlank := false
-	calcStack := aocutilites.Stack[int]{}
-
-	for col := 0; col < cols; col++ {
-		isBlankCol := true
-		for rowCount := 0; rowCount <= rows; rowCount++ {
-			if output[rowCount][col] != " " {
-				isBlankCol = false
-				break
+		for row := range b.Height() {
+			n, err := readNumber(b.Row(row))
+			if err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", row+1, b.Start+1, err)
 			}
+			p.rows = append(p.rows, operand{value: n, line: row + 1})
 		}
-
-		if !isBlankCol {
-			nums := []string{}
-			for rowCount := 0; rowCount <= rows; rowCount++ {
-				currentDigit := output[rowCount][col]
-				if currentDigit != " " {
-					nums = append(nums, currentDigit)
-				}
+		for col := range b.Width() {
+			n, err := readNumber(b.Col(col))
+			if err != nil {
+				return nil, fmt.Errorf("column %d: %w", b.Start+col+1, err)
 			}
+			p.cols = append(p.cols, operand{value: n, col: b.Start + col + 1})
+		}
 
-			if len(nums) > 0 {
-
-				if opIndex < len(operations) {
-					op = operations[opIndex]
-				}
-
-				tmpNums := []int{}
+		sheet[i] = p
+	}
 
-				for _, n := range nums {
-					num, err := strconv.Atoi(n)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Error converting string to int: %v\n", err)
-						return total
-					}
-					tmpNums = append(tmpNums, num)
-				}
+	return sheet, nil
+}
 
-				numval := combineDigits(tmpNums)
-				calcStack.Push(numval)
+func (solver) Part1(input Worksheet) (int, error) {
+	return solve(input, func(p problem) []operand { return p.rows }).Result()
+}
 
-			}
+func (solver) Part2(input Worksheet) (int, error) {
+	return solve(input, func(p problem) []operand { return p.cols }).Result()
+}
 
-			prevWasBlank = false
-		} else {
-			// Hit a blank column - do the operation on the stack
-			// and switch to next operation
+// operand is a number from the worksheet and where it was, so an overflow
+// can be traced back to the input. line and col are 1-based, 0 if unknown.
+type operand struct {
+	value     int
+	line, col int
+}
 
-			stackTotal := stackMaths(op, calcStack)
+// readNumber reads a number padded with spaces on either side.
+func readNumber(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
 
-			total += stackTotal
-			calcStack = aocutilites.Stack[int]{}
+// solve adds up the answers to every problem, reading each one's numbers
+// with operands.
+func solve(sheet Worksheet, operands func(problem) []operand) aocutilites.Int {
+	total := aocutilites.NewInt(0)
 
-			if !prevWasBlank {
-				opIndex++
-			}
-			prevWasBlank = true
+	for _, p := range sheet {
+		calcStack := aocutilites.Stack[operand]{}
+		for _, o := range operands(p) {
+			calcStack.Push(o)
 		}
-	}
-	// Do the final sum
-	stackTotal := stackMaths(op, calcStack)
-	total += stackTotal
-
-	return total
-}
 
-func main() {
-	input := "input.txt"
-	operands, operations, err := readInput(input)
-
-	if err != nil {
-		os.Exit(1)
+		total = total.Add(stackMaths(p.op, calcStack))
 	}
 
-	total := part1(operands, operations)
-	fmt.Printf("Grand Total Part 1: %d\n", total)
-
-	// Took a different approach for part 2
-	total = part2(input)
-	fmt.Printf("Grand Total Part 2: %d\n", total)
+	return total
 }
 
 // Should refactor this to enable the operations to be pushed onto the stack
 // with the operands.
-func stackMaths(op string, stack aocutilites.Stack[int]) int {
-	total := 0
+func stackMaths(op string, stack aocutilites.Stack[operand]) aocutilites.Int {
+	total := aocutilites.NewInt(0)
 
 	for !stack.IsEmpty() {
-		val, _ := stack.Pop()
+		o, _ := stack.Pop()
+		val := aocutilites.NewInt(o.value)
 
 		switch op {
 		case "+":
-			total += val
+			total = total.Add(val)
 		case "-":
-			total -= val
+			total = total.Sub(val)
 		case "*":
-			if total == 0 {
-				total = 1
+			// An overflowed total reads as 0 too, so keep its error
+			if total.Sign() == 0 && total.Err() == nil {
+				total = aocutilites.NewInt(1)
 			}
-			total *= val
+			total = total.Mul(val)
 		case "/":
-			if val != 0 {
-				total /= val
+			if o.value != 0 {
+				total = total.Div(val)
 			}
 		}
+		total = total.At(o.line, o.col)
 	}
 
 	return total
 }
-
-func combineDigits(digits []int) int {
-	result := 0
-	for _, digit := range digits {
-		result = result*10 + digit
-	}
-	return result
-}
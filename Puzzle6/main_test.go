@@ -0,0 +1,59 @@
+package puzzle6
+
+import (
+	"os"
+	"testing"
+
+	aocutilites "AOC2025/aocutilities"
+	"AOC2025/aocutilities/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part1 int
+		part2 int
+	}{
+		{"example", "testdata/example.txt", 4277556, 3263827},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aoctest.Check(t, solver{}, tt.input, tt.part1, tt.part2)
+		})
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	f, err := os.Open("testdata/overflow.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	input, err := solver{}.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		mode aocutilites.OverflowMode
+		want string
+	}{
+		{aocutilites.OverflowCheck, "line 2: 9999999999 * 9999999999 overflows int"},
+		{aocutilites.OverflowBig, "line 2: 9999999999 * 9999999999 overflows int; the exact answer is 999999999700000000030000000005"},
+	}
+
+	defer aocutilites.SetOverflowMode(aocutilites.CurrentOverflowMode())
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			aocutilites.SetOverflowMode(tt.mode)
+
+			_, err := solver{}.Part1(input)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("Part1() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
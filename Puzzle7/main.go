@@ -1,84 +1,85 @@
-package main
+package puzzle7
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-)
+	_ "embed"
+	"io"
 
-func readInput(inputFile string) ([][]string, error) {
-	output := [][]string{}
+	aocutilites "AOC2025/aocutilities"
+)
 
-	file, err := os.Open(inputFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return output, err
-	}
-	defer file.Close()
+type solver struct{}
 
-	scanner := bufio.NewScanner(file)
-	lineCount := 0
-	for scanner.Scan() {
-		line := scanner.Text()
+//go:embed testdata/example.txt
+var example string
 
-		// convert line to a slice of characters
-		characters := []string{}
-		for _, char := range line {
-			characters = append(characters, string(char))
-		}
-		output = append(output, characters)
-		lineCount++
+func init() {
+	aocutilites.Register(7, solver{})
+	aocutilites.RegisterInput(7, "example", example)
+}
 
+func (solver) Parse(r io.Reader) (*aocutilites.Grid[rune], error) {
+	lines, err := aocutilites.ReadLines(r)
+	if err != nil {
+		return nil, err
 	}
+	return aocutilites.ParseGrid(lines, aocutilites.Runes)
+}
 
-	return output, nil
+func (solver) Part1(input *aocutilites.Grid[rune]) (int, error) {
+	totalSplits, _ := solution(input)
+	return totalSplits, nil
+}
 
+func (solver) Part2(input *aocutilites.Grid[rune]) (int, error) {
+	_, totalPaths := solution(input)
+	return totalPaths.Result()
 }
 
-func solution(input [][]string) (part1 int, part2 int) {
+// The path counts double at every splitter, so they're kept as checked ints
+func solution(input *aocutilites.Grid[rune]) (part1 int, part2 aocutilites.Int) {
 	totalSplits := 0
 
-	if len(input) == 0 {
-		return 0, 0
+	if input.Height == 0 {
+		return 0, aocutilites.NewInt(0)
 	}
 
-	width := len(input[0])
-	pathTracker := make([]int, width)
+	width := input.Width
+	pathTracker := make([]aocutilites.Int, width)
 
 	// Track current beam positions (true = beam present at this position)
 	currentBeamPath := make([]bool, width)
 
 	// Find starting position in the first row
-	startIndex := findIndices(input[0], "S")
-	if len(startIndex) > 0 {
-		currentBeamPath[startIndex[0]] = true
-		pathTracker[startIndex[0]] = 1
+	if start, ok := input.Find(aocutilites.Equal('S')); ok && start.Row == 0 {
+		currentBeamPath[start.Col] = true
+		pathTracker[start.Col] = aocutilites.NewInt(1)
 	}
 
 	// Process each subsequent row
-	for lineNo := 1; lineNo < len(input); lineNo++ {
-		line := input[lineNo]
+	for lineNo := 1; lineNo < input.Height; lineNo++ {
+		line := input.Row(lineNo)
 		nextBeamPath := make([]bool, width)
 
 		// For each position, check if there's a beam
 		for i := 0; i < width; i++ {
 			if currentBeamPath[i] {
-				if line[i] == "^" {
+				if line[i] == '^' {
 					// Beam hits a splitter
 					totalSplits++
 
-					pathTracker[i-1] += pathTracker[i]
-					pathTracker[i+1] += pathTracker[i]
-					pathTracker[i] = 0
-
+					// A beam split off the edge of the manifold is lost,
+					// along with its paths
 					// Add left beam path
 					if i > 0 {
 						nextBeamPath[i-1] = true
+						pathTracker[i-1] = pathTracker[i-1].Add(pathTracker[i]).At(lineNo+1, i)
 					}
 					// Add right beam path
 					if i < width-1 {
 						nextBeamPath[i+1] = true
+						pathTracker[i+1] = pathTracker[i+1].Add(pathTracker[i]).At(lineNo+1, i+2)
 					}
+					pathTracker[i] = aocutilites.NewInt(0)
 
 				} else {
 					// No splitter - beam continues straight down
@@ -90,35 +91,12 @@ func solution(input [][]string) (part1 int, part2 int) {
 		currentBeamPath = nextBeamPath
 	}
 
-	totalPaths := 0
+	totalPaths := aocutilites.NewInt(0)
 
-	for _, paths := range pathTracker {
-		totalPaths += paths
+	// The paths all end on the last line
+	for col, paths := range pathTracker {
+		totalPaths = totalPaths.Add(paths).At(input.Height, col+1)
 	}
 
 	return totalSplits, totalPaths
 }
-
-func main() {
-	inputFile := "input.txt"
-	input, err := readInput(inputFile)
-
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		os.Exit(1)
-	}
-
-	totalSpits, totalPaths := solution(input)
-	fmt.Printf("Part 1 Total Spits: %d\nPart 2 Total Paths: %d\n", totalSpits, totalPaths)
-
-}
-
-func findIndices(chars []string, target string) []int {
-	indices := []int{}
-	for i, char := range chars {
-		if char == target {
-			indices = append(indices, i)
-		}
-	}
-	return indices
-}
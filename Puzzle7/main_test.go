@@ -0,0 +1,61 @@
+package puzzle7
+
+import (
+	"strings"
+	"testing"
+
+	aocutilites "AOC2025/aocutilities"
+	"AOC2025/aocutilities/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part1 int
+		part2 int
+	}{
+		{"example", "testdata/example.txt", 21, 40},
+		{"splitters on the edges", "testdata/edges.txt", 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aoctest.Check(t, solver{}, tt.input, tt.part1, tt.part2)
+		})
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	// 63 rows of splitters double the paths 63 times. No single column gets
+	// past C(63, 31), so only adding them up at the end overflows.
+	width := 129
+	rows := []string{strings.Repeat(".", 64) + "S" + strings.Repeat(".", 64)}
+	for range 63 {
+		rows = append(rows, strings.Repeat("^", width))
+	}
+	input, err := solver{}.Parse(strings.NewReader(strings.Join(rows, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		mode aocutilites.OverflowMode
+		want string
+	}{
+		{aocutilites.OverflowCheck, "line 64, column "},
+		{aocutilites.OverflowBig, "the exact answer is 9223372036854775808"},
+	}
+
+	defer aocutilites.SetOverflowMode(aocutilites.CurrentOverflowMode())
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			aocutilites.SetOverflowMode(tt.mode)
+
+			_, err := solver{}.Part2(input)
+			if err == nil || !strings.HasPrefix(err.Error(), "line 64, column ") || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Part2() error = %v, want one at line 64 containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,55 @@
-package main
+package puzzle8
 
 import (
-	"bufio"
+	_ "embed"
 	"encoding/json"
-	"fmt"
+	"io"
 	"math"
 	"net/http"
-	"os"
-	"sort"
+
+	aocutilites "AOC2025/aocutilities"
+	"AOC2025/aocutilities/seq"
 )
 
+// The example input only makes 10 connections, the real one makes 1000.
+type solver struct {
+	numConnections int
+}
+
+//go:embed testdata/example.txt
+var example string
+
+func init() {
+	aocutilites.Register(8, solver{numConnections: 1000})
+	aocutilites.RegisterInputSolver(8, "example", example, solver{numConnections: 10})
+}
+
+func (solver) Parse(r io.Reader) ([]Point3D, error) {
+	return readInput(r)
+}
+
+func (s solver) Part1(points []Point3D) (int, error) {
+	_, answer := part1(points, s.numConnections)
+	return answer, nil
+}
+
+func (solver) Part2(points []Point3D) (int, error) {
+	_, answer := part2(points)
+	return answer, nil
+}
+
+// Visualise it for fun
+func (s solver) Visualise(points []Point3D, part int) error {
+	var scene SceneData
+	if part == 1 {
+		scene, _ = part1(points, s.numConnections)
+	} else {
+		scene, _ = part2(points)
+	}
+
+	return visualise(scene)
+}
+
 type Point3D struct {
 	X float64 `json:"x"`
 	Y float64 `json:"y"`
@@ -27,33 +67,14 @@ type SceneData struct {
 	Lines  []Pair    `json:"lines"`
 }
 
-func readInput(inputFile string) ([]Point3D, error) {
-
-	points := []Point3D{}
+func readInput(r io.Reader) ([]Point3D, error) {
 
-	file, err := os.Open(inputFile)
+	lines, err := aocutilites.ReadLines(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		return points, err
+		return nil, err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		var point Point3D
 
-		_, err := fmt.Sscanf(line, "%f,%f,%f", &point.X, &point.Y, &point.Z)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing line: %v\n", err)
-			continue
-		}
-		points = append(points, point)
-
-	}
-
-	return points, nil
+	return aocutilites.ParseLines[Point3D](lines, "{X},{Y},{Z}")
 }
 
 func makeSceneData(points []Point3D, lines []Pair) SceneData {
@@ -66,7 +87,7 @@ func makeSceneData(points []Point3D, lines []Pair) SceneData {
 	return scene
 }
 
-func visualise(scene SceneData) {
+func visualise(scene SceneData) error {
 
 	// Work out the circuits
 
@@ -83,48 +104,54 @@ func visualise(scene SceneData) {
 	})
 
 	println("Open http://localhost:8080 to see the visulaisation")
-	http.ListenAndServe(":8080", nil)
+	return http.ListenAndServe(":8080", nil)
 }
 
-func part1(points []Point3D, numConnections int) (SceneData, int) {
-	// Initialize Union-Find structure
-	uf := NewUnionFind(len(points))
+// IndexedPair is two points, by index, and the distance between them
+type IndexedPair struct {
+	i        int
+	j        int
+	Distance float64
+}
+
+// Generate all the pairs and their distances
+func allPairs(points []Point3D) []IndexedPair {
+	pairs := make([]IndexedPair, 0, len(points)*(len(points)-1)/2)
 
-	type IndexedPair struct {
-		i        int
-		j        int
-		Distance float64
+	for i, j := range seq.PairIndices(len(points)) {
+		dx := points[i].X - points[j].X
+		dy := points[i].Y - points[j].Y
+		dz := points[i].Z - points[j].Z
+
+		pairs = append(pairs, IndexedPair{
+			i:        i,
+			j:        j,
+			Distance: math.Sqrt(dx*dx + dy*dy + dz*dz),
+		})
 	}
 
-	pairs := []IndexedPair{}
+	return pairs
+}
 
-	// Generate all the pairs and their distances
-	for i := 0; i < len(points); i++ {
-		for j := i + 1; j < len(points); j++ {
-			dx := points[i].X - points[j].X
-			dy := points[i].Y - points[j].Y
-			dz := points[i].Z - points[j].Z
-			dist := math.Sqrt(dx*dx + dy*dy + dz*dz)
+func part1(points []Point3D, numConnections int) (SceneData, int) {
+	// Initialize Union-Find structure
+	uf := aocutilites.NewUnionFind(len(points))
 
-			pair := IndexedPair{
-				i:        i,
-				j:        j,
-				Distance: dist,
-			}
-			pairs = append(pairs, pair)
-		}
-	}
+	pairs := allPairs(points)
 
-	// Sort the pairs by distance
-	sort.Slice(pairs, func(i, j int) bool {
-		return pairs[i].Distance < pairs[j].Distance
-	})
+	// Only the shortest numConnections pairs are needed, so heapify the pairs
+	// rather than sorting all of them
+	shortest := aocutilites.PriorityQueue[IndexedPair, float64]{}
+	shortest.Init(pairs, func(p IndexedPair) float64 { return p.Distance })
 
 	// Connect the shortest pairs
 	connectedPairs := []Pair{}
 
-	for i := 0; i < numConnections && i < len(pairs); i++ {
-		pair := pairs[i]
+	for i := 0; i < numConnections; i++ {
+		pair, _, ok := shortest.Pop()
+		if !ok {
+			break
+		}
 
 		// Try to union the two points
 		connected := uf.Union(pair.i, pair.j)
@@ -139,17 +166,18 @@ func part1(points []Point3D, numConnections int) (SceneData, int) {
 		}
 	}
 
-	// Get circuit sizes
-	sizes := uf.GetSizes()
-	sort.Slice(sizes, func(i, j int) bool {
-		return sizes[i] > sizes[j]
-	})
+	// Each circuit's size is already known from its root
+	circuits := aocutilites.Counter[int]{}
+	sizes := uf.Sizes()
+	for i, root := range uf.Roots() {
+		circuits.AddN(root, sizes[i])
+	}
 
-	//fmt.Printf("After processing %d pairs, circuit sizes: %v\n", numConnections, sizes)
+	//fmt.Printf("After processing %d pairs, circuits: %v\n", numConnections, circuits.MostCommon(-1))
 
 	answer := 1
-	for i := 0; i < 3 && i < len(sizes); i++ {
-		answer *= sizes[i]
+	for _, circuit := range circuits.MostCommon(3) {
+		answer *= circuit.Count
 	}
 
 	scene := makeSceneData(points, connectedPairs)
@@ -158,47 +186,24 @@ func part1(points []Point3D, numConnections int) (SceneData, int) {
 
 func part2(points []Point3D) (SceneData, int) {
 	// Initialize Union-Find structure
-	uf := NewUnionFind(len(points))
+	uf := aocutilites.NewUnionFind(len(points))
 
-	type IndexedPair struct {
-		i        int
-		j        int
-		Distance float64
-	}
+	pairs := allPairs(points)
 
-	pairs := []IndexedPair{}
-
-	// Generate all the pairs and their distances
-	for i := 0; i < len(points); i++ {
-		for j := i + 1; j < len(points); j++ {
-			dx := points[i].X - points[j].X
-			dy := points[i].Y - points[j].Y
-			dz := points[i].Z - points[j].Z
-			dist := math.Sqrt(dx*dx + dy*dy + dz*dz)
-
-			pair := IndexedPair{
-				i:        i,
-				j:        j,
-				Distance: dist,
-			}
-			pairs = append(pairs, pair)
-		}
-	}
-
-	// Sort the pairs by distance
-	sort.Slice(pairs, func(i, j int) bool {
-		return pairs[i].Distance < pairs[j].Distance
-	})
+	// Usually only a fraction of the pairs are needed before everything is
+	// connected, so take them shortest first from a heap
+	shortest := aocutilites.PriorityQueue[IndexedPair, float64]{}
+	shortest.Init(pairs, func(p IndexedPair) float64 { return p.Distance })
 
 	// Connect pairs until we have only 1 circuit
 	connectedPairs := []Pair{}
 	var lastPair IndexedPair
-	numCircuits := len(points) // Start with each point in its own circuit
 
-	for _, pair := range pairs {
+	for !shortest.IsEmpty() {
+		pair, _, _ := shortest.Pop()
+
 		// Try to union the two points
 		if uf.Union(pair.i, pair.j) {
-			numCircuits-- // One less circuit after successful union
 			lastPair = pair
 
 			// Store for visualization
@@ -209,7 +214,7 @@ func part2(points []Point3D) (SceneData, int) {
 			})
 
 			// Check if we're done (all in one circuit)
-			if numCircuits == 1 {
+			if uf.Count() == 1 {
 				break
 			}
 		}
@@ -224,91 +229,6 @@ func part2(points []Point3D) (SceneData, int) {
 	return scene, answer
 }
 
-// UnionFind data structure for tracking connected components (circuits)
-type UnionFind struct {
-	parent map[int]int
-	size   map[int]int
-}
-
-func NewUnionFind(n int) *UnionFind {
-	uf := &UnionFind{
-		parent: make(map[int]int),
-		size:   make(map[int]int),
-	}
-	for i := 0; i < n; i++ {
-		uf.parent[i] = i
-		uf.size[i] = 1
-	}
-	return uf
-}
-
-func (uf *UnionFind) Find(x int) int {
-	if uf.parent[x] != x {
-		uf.parent[x] = uf.Find(uf.parent[x]) // Path compression
-	}
-	return uf.parent[x]
-}
-
-func (uf *UnionFind) Union(x, y int) bool {
-	rootX := uf.Find(x)
-	rootY := uf.Find(y)
-
-	if rootX == rootY {
-		return false // Already in same set
-	}
-
-	// Union by size
-	if uf.size[rootX] < uf.size[rootY] {
-		uf.parent[rootX] = rootY
-		uf.size[rootY] += uf.size[rootX]
-	} else {
-		uf.parent[rootY] = rootX
-		uf.size[rootX] += uf.size[rootY]
-	}
-	return true
-}
-
-func (uf *UnionFind) GetSizes() []int {
-	sizeMap := make(map[int]int)
-	for i := range uf.parent {
-		root := uf.Find(i)
-		sizeMap[root] = uf.size[root]
-	}
-
-	sizes := []int{}
-	for _, size := range sizeMap {
-		sizes = append(sizes, size)
-	}
-	return sizes
-}
-
-func main() {
-	// Configuration
-	inputFile := "input.txt" // "testinput.txt" or "input.txt"
-	numConnections := 1000
-	runPart2 := true // Set to true for Part 2, false for Part 1
-
-	input, err := readInput(inputFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		os.Exit(1)
-	}
-
-	var scene SceneData
-	var answer int
-
-	if runPart2 {
-		scene, answer = part2(input)
-		fmt.Printf("Part 2 - sum of last two connected junctions: %d\n\n", answer)
-	} else {
-		scene, answer = part1(input, numConnections)
-		fmt.Printf("Part 1 - sum of three largest circuits: %d\n\n", answer)
-	}
-
-	// Visualise it for fun
-	visualise(scene)
-}
-
 const htmlTemplate = `<!DOCTYPE html>
 <html>
 <head>
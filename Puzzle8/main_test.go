@@ -0,0 +1,35 @@
+package puzzle8
+
+import (
+	"strings"
+	"testing"
+
+	"AOC2025/aocutilities/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		numConnections int
+		part1          int
+		part2          int
+	}{
+		// The puzzle's example only makes 10 connections
+		{"example", "testdata/example.txt", 10, 40, 25272},
+		{"example with real connection count", "testdata/example.txt", 1000, 20, 25272},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aoctest.Check(t, solver{numConnections: tt.numConnections}, tt.input, tt.part1, tt.part2)
+		})
+	}
+}
+
+func TestBadInput(t *testing.T) {
+	_, err := solver{}.Parse(strings.NewReader("162,817,812\n57,618\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("Parse error = %v, want one naming line 2", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package aoctest runs a day's Solver against its example inputs.
+//
+// Each PuzzleN directory keeps its examples in testdata, e.g.
+// testdata/example.txt, and lists them with their expected answers in a
+// table in its tests.
+package aoctest
+
+import (
+	"os"
+	"testing"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+// Check parses inputFile and compares both parts with the expected answers.
+// Each part is run twice on the same parsed input, since parts must not
+// modify what Parse returned.
+func Check[T any](t *testing.T, s aocutilites.Solver[T], inputFile string, want1, want2 int) {
+	t.Helper()
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	input, err := s.Parse(file)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	parts := []struct {
+		name  string
+		solve func(T) (int, error)
+		want  int
+	}{
+		{"Part1", s.Part1, want1},
+		{"Part2", s.Part2, want2},
+	}
+
+	for _, part := range parts {
+		for run := 1; run <= 2; run++ {
+			got, err := part.solve(input)
+			if err != nil {
+				t.Errorf("%s run %d: %v", part.name, run, err)
+				break
+			}
+			if got != part.want {
+				t.Errorf("%s run %d = %d, want %d", part.name, run, got, part.want)
+				break
+			}
+		}
+	}
+}
@@ -38,21 +38,16 @@ func (s *Stack[T]) Size() int {
 
 // String and array manipulation
 
+// SplitWords splits s on spaces and tabs. Use Tokenize to also get each
+// word's column.
 func SplitWords(s string) []string {
-	var words []string
-	currentWord := ""
-	for _, char := range s {
-		if char == ' ' || char == '\t' {
-			if currentWord != "" {
-				words = append(words, currentWord)
-				currentWord = ""
-			}
-		} else {
-			currentWord += string(char)
-		}
+	tokens := TokenizeAny(s, " \t")
+	if tokens == nil {
+		return nil
 	}
-	if currentWord != "" {
-		words = append(words, currentWord)
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = t.Text
 	}
 	return words
 }
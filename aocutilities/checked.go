@@ -0,0 +1,239 @@
+package aocutilites
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Checked arithmetic
+//
+// Int is an int for solvers that accumulate big numbers. What happens when
+// arithmetic on it overflows depends on the overflow mode, which the runner
+// sets once before solving:
+//
+//   - OverflowWrap, the default, is plain int arithmetic with no checks.
+//   - OverflowCheck stops at the first overflow and reports it.
+//   - OverflowBig reports the first overflow too, but carries on in math/big
+//     so the exact answer is still known.
+//
+// Solvers say where each value came from with At, so the report can point
+// at the input.
+
+type OverflowMode int
+
+const (
+	OverflowWrap OverflowMode = iota
+	OverflowCheck
+	OverflowBig
+)
+
+var overflowModeNames = [...]string{"wrap", "check", "big"}
+
+func (m OverflowMode) String() string {
+	return overflowModeNames[m]
+}
+
+// ParseOverflowMode accepts "wrap", "check" or "big".
+func ParseOverflowMode(s string) (OverflowMode, error) {
+	for i, name := range overflowModeNames {
+		if s == name {
+			return OverflowMode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown overflow mode %q (want wrap, check or big)", s)
+}
+
+var overflowMode OverflowMode
+
+// SetOverflowMode sets how every Int handles overflow. It isn't safe to call
+// while a solver is running.
+func SetOverflowMode(m OverflowMode) {
+	overflowMode = m
+}
+
+func CurrentOverflowMode() OverflowMode {
+	return overflowMode
+}
+
+// OverflowError describes the first operation that didn't fit in an int.
+// Line and Col are 1-based, and 0 when the solver didn't say.
+type OverflowError struct {
+	Op        string
+	X, Y      int
+	Line, Col int
+}
+
+func (e *OverflowError) Error() string {
+	where := ""
+	switch {
+	case e.Line > 0 && e.Col > 0:
+		where = fmt.Sprintf("line %d, column %d: ", e.Line, e.Col)
+	case e.Line > 0:
+		where = fmt.Sprintf("line %d: ", e.Line)
+	case e.Col > 0:
+		where = fmt.Sprintf("column %d: ", e.Col)
+	}
+	return fmt.Sprintf("%s%d %s %d overflows int", where, e.X, e.Op, e.Y)
+}
+
+// Int is an int that notices overflow. The zero value is 0. Like float NaN,
+// an overflow sticks to every value computed from it.
+type Int struct {
+	n   int
+	big *big.Int // the exact value, once it has outgrown n in OverflowBig mode
+	err *OverflowError
+}
+
+func NewInt(n int) Int {
+	return Int{n: n}
+}
+
+func (x Int) Add(y Int) Int { return x.apply("+", y) }
+func (x Int) Sub(y Int) Int { return x.apply("-", y) }
+func (x Int) Mul(y Int) Int { return x.apply("*", y) }
+
+// Div truncates towards zero like int division, and panics on zero.
+func (x Int) Div(y Int) Int { return x.apply("/", y) }
+
+func (x Int) AddInt(n int) Int { return x.Add(Int{n: n}) }
+func (x Int) MulInt(n int) Int { return x.Mul(Int{n: n}) }
+
+func (x Int) apply(op string, y Int) Int {
+	if overflowMode == OverflowWrap {
+		return Int{n: wrapOp(op, x.n, y.n)}
+	}
+
+	err := x.err
+	if err == nil {
+		err = y.err
+	}
+
+	if x.big != nil || y.big != nil {
+		return Int{big: bigOp(op, x.Big(), y.Big()), err: err}
+	}
+	if err != nil && overflowMode == OverflowCheck {
+		return Int{err: err}
+	}
+
+	if n, ok := checkedOp(op, x.n, y.n); ok {
+		return Int{n: n, err: err}
+	}
+
+	if err == nil {
+		err = &OverflowError{Op: op, X: x.n, Y: y.n}
+	}
+	if overflowMode == OverflowBig {
+		return Int{big: bigOp(op, x.Big(), y.Big()), err: err}
+	}
+	return Int{err: err}
+}
+
+func wrapOp(op string, x, y int) int {
+	switch op {
+	case "+":
+		return x + y
+	case "-":
+		return x - y
+	case "*":
+		return x * y
+	default:
+		return x / y
+	}
+}
+
+func checkedOp(op string, x, y int) (int, bool) {
+	switch op {
+	case "+":
+		return CheckedAdd(x, y)
+	case "-":
+		return CheckedSub(x, y)
+	case "*":
+		return CheckedMul(x, y)
+	default:
+		if x == math.MinInt && y == -1 {
+			return 0, false
+		}
+		return x / y, true
+	}
+}
+
+func bigOp(op string, x, y *big.Int) *big.Int {
+	z := new(big.Int)
+	switch op {
+	case "+":
+		return z.Add(x, y)
+	case "-":
+		return z.Sub(x, y)
+	case "*":
+		return z.Mul(x, y)
+	default:
+		return z.Quo(x, y)
+	}
+}
+
+// At records where in the input x came from, if an overflow on the way to it
+// hasn't been placed yet.
+func (x Int) At(line, col int) Int {
+	if x.err != nil && x.err.Line == 0 && x.err.Col == 0 {
+		placed := *x.err
+		placed.Line, placed.Col = line, col
+		x.err = &placed
+	}
+	return x
+}
+
+// Big returns the exact value as a big.Int.
+func (x Int) Big() *big.Int {
+	if x.big != nil {
+		return new(big.Int).Set(x.big)
+	}
+	return big.NewInt(int64(x.n))
+}
+
+// Cmp compares x and y like big.Int.Cmp.
+func (x Int) Cmp(y Int) int {
+	if x.big == nil && y.big == nil {
+		switch {
+		case x.n < y.n:
+			return -1
+		case x.n > y.n:
+			return 1
+		}
+		return 0
+	}
+	return x.Big().Cmp(y.Big())
+}
+
+// Sign returns -1, 0 or 1.
+func (x Int) Sign() int {
+	if x.big != nil {
+		return x.big.Sign()
+	}
+	return x.Cmp(Int{})
+}
+
+// Err returns the first overflow, if there was one.
+func (x Int) Err() error {
+	if x.err == nil {
+		return nil
+	}
+	return x.err
+}
+
+// Result is the value for a solver to return. It's an error if there was an
+// overflow at any point. In OverflowBig mode the error includes the exact
+// answer, and if that came back into range it's returned as well.
+func (x Int) Result() (int, error) {
+	if x.big != nil {
+		err := fmt.Errorf("%w; the exact answer is %s", x.err, x.big)
+		if x.big.IsInt64() {
+			return int(x.big.Int64()), err
+		}
+		return 0, err
+	}
+	if x.err != nil {
+		return 0, x.err
+	}
+	return x.n, nil
+}
@@ -0,0 +1,103 @@
+package aocutilites
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func withOverflowMode(t *testing.T, m OverflowMode) {
+	t.Helper()
+	old := CurrentOverflowMode()
+	SetOverflowMode(m)
+	t.Cleanup(func() { SetOverflowMode(old) })
+}
+
+func TestIntWrap(t *testing.T) {
+	withOverflowMode(t, OverflowWrap)
+
+	x := NewInt(math.MaxInt).AddInt(1)
+	if n, err := x.Result(); err != nil || n != math.MinInt {
+		t.Fatalf("MaxInt + 1 = %d, %v, want it to wrap", n, err)
+	}
+}
+
+func TestIntCheck(t *testing.T) {
+	withOverflowMode(t, OverflowCheck)
+
+	x := NewInt(3).MulInt(4).AddInt(-2)
+	if n, err := x.Result(); err != nil || n != 10 {
+		t.Fatalf("3 * 4 - 2 = %d, %v", n, err)
+	}
+
+	big := NewInt(math.MaxInt / 2)
+	x = big.At(1, 0).Add(big).At(2, 0).AddInt(2).At(3, 7).Sub(NewInt(5)).At(4, 0)
+
+	_, err := x.Result()
+	var oe *OverflowError
+	if !errors.As(err, &oe) {
+		t.Fatalf("Result() error = %v, want an OverflowError", err)
+	}
+	// The first overflow is the one reported, with where it happened
+	want := OverflowError{Op: "+", X: math.MaxInt - 1, Y: 2, Line: 3, Col: 7}
+	if *oe != want {
+		t.Fatalf("got %+v, want %+v", *oe, want)
+	}
+	if !strings.HasPrefix(oe.Error(), "line 3, column 7: ") {
+		t.Fatalf("Error() = %q", oe.Error())
+	}
+
+	if _, err := NewInt(math.MinInt).Div(NewInt(-1)).Result(); err == nil {
+		t.Fatal("MinInt / -1 didn't overflow")
+	}
+	if _, err := NewInt(math.MinInt).Sub(NewInt(1)).Result(); err == nil {
+		t.Fatal("MinInt - 1 didn't overflow")
+	}
+}
+
+func TestIntBig(t *testing.T) {
+	withOverflowMode(t, OverflowBig)
+
+	// 10^20 doesn't fit, but dividing it back down does
+	x := NewInt(1)
+	for range 20 {
+		x = x.MulInt(10)
+	}
+	if x.Cmp(NewInt(math.MaxInt)) <= 0 || x.Sign() != 1 {
+		t.Fatal("10^20 should compare above MaxInt")
+	}
+
+	_, err := x.Result()
+	if err == nil || !strings.Contains(err.Error(), "the exact answer is 100000000000000000000") {
+		t.Fatalf("Result() error = %v", err)
+	}
+	var oe *OverflowError
+	if !errors.As(err, &oe) || oe.Y != 10 {
+		t.Fatalf("error doesn't wrap the first overflow: %v", err)
+	}
+
+	// The answer is back in range, but the overflow on the way still counts
+	back := x.Div(NewInt(1_000_000))
+	n, err := back.Result()
+	if n != 100_000_000_000_000 {
+		t.Fatalf("10^20 / 10^6 = %d", n)
+	}
+	if err == nil || !errors.As(err, &oe) || !strings.Contains(err.Error(), "the exact answer is 100000000000000") {
+		t.Fatalf("Result() error = %v, want the overflow on the way", err)
+	}
+	if back.Err() == nil {
+		t.Fatal("Err() lost the overflow on the way")
+	}
+}
+
+func TestParseOverflowMode(t *testing.T) {
+	for _, m := range []OverflowMode{OverflowWrap, OverflowCheck, OverflowBig} {
+		if got, err := ParseOverflowMode(m.String()); err != nil || got != m {
+			t.Fatalf("ParseOverflowMode(%q) = %v, %v", m, got, err)
+		}
+	}
+	if _, err := ParseOverflowMode("saturate"); err == nil {
+		t.Fatal("unknown mode didn't return an error")
+	}
+}
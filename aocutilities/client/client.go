@@ -0,0 +1,224 @@
+// Package client talks to the Advent of Code website. It downloads puzzle
+// inputs, caching them on disk so each one is only fetched once, and
+// submits answers.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	Year           = 2025
+
+	// Advent of Code asks automated tools to identify themselves.
+	UserAgent = "github.com/andrewsjg/AOC2025 (Go input client)"
+
+	SessionEnv     = "AOC_SESSION"
+	SessionFileEnv = "AOC_SESSION_FILE"
+	BaseURLEnv     = "AOC_BASE_URL"
+)
+
+var ErrNoSession = errors.New("no session token: set " + SessionEnv + " or write it to the session file")
+
+type Client struct {
+	// BaseURL defaults to DefaultBaseURL. Point it at a stand-in server for
+	// testing.
+	BaseURL string
+	Session string
+
+	// CacheDir is the repository root. Inputs are cached in the same
+	// PuzzleN/input.txt files that the runner reads.
+	CacheDir string
+
+	HTTPClient *http.Client
+}
+
+// New returns a client using the session token and base URL from the
+// environment or the session file.
+func New(cacheDir string) *Client {
+	c := &Client{
+		BaseURL:    os.Getenv(BaseURLEnv),
+		CacheDir:   cacheDir,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	if c.BaseURL == "" {
+		c.BaseURL = DefaultBaseURL
+	}
+
+	// A missing token only matters once something has to be fetched.
+	c.Session, _ = LoadSession()
+	return c
+}
+
+// LoadSession returns the session token from $AOC_SESSION, or failing that
+// from the file named by $AOC_SESSION_FILE (default <config dir>/aoc/session).
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+
+	path := os.Getenv(SessionFileEnv)
+	if path == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", ErrNoSession
+		}
+		path = filepath.Join(configDir, "aoc", "session")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if err != nil {
+		return "", err
+	}
+
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", ErrNoSession
+	}
+	return session, nil
+}
+
+// InputPath is where the input for a day is cached.
+func (c *Client) InputPath(day int) string {
+	return filepath.Join(c.CacheDir, fmt.Sprintf("Puzzle%d", day), "input.txt")
+}
+
+// Input returns the puzzle input for a day, downloading it only if it is not
+// already cached.
+func (c *Client) Input(ctx context.Context, day int) ([]byte, error) {
+	path := c.InputPath(day)
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	data, err = c.get(ctx, fmt.Sprintf("/%d/day/%d/input", Year, day))
+	if err != nil {
+		return nil, fmt.Errorf("fetching day %d input: %w", day, err)
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return nil, fmt.Errorf("caching day %d input: %w", day, err)
+	}
+	return data, nil
+}
+
+// do sends an authenticated request and returns the body of a 200 response.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(path), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) url(path string) string {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	return strings.TrimRight(base, "/") + path
+}
+
+// writeFileAtomic makes sure an interrupted download never leaves a partial
+// input in the cache.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var (
+	preRe     = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	exampleRe = regexp.MustCompile(`(?i)for example`)
+)
+
+// Example returns the first example input from a day's puzzle page: the
+// first code block after the words "for example", or failing that the first
+// code block on the page.
+func (c *Client) Example(ctx context.Context, day int) (string, error) {
+	page, err := c.get(ctx, fmt.Sprintf("/%d/day/%d", Year, day))
+	if err != nil {
+		return "", fmt.Errorf("fetching day %d puzzle: %w", day, err)
+	}
+	return ParseExample(string(page))
+}
+
+func ParseExample(page string) (string, error) {
+	text := page
+	if loc := exampleRe.FindStringIndex(page); loc != nil && preRe.MatchString(page[loc[1]:]) {
+		text = page[loc[1]:]
+	}
+
+	m := preRe.FindStringSubmatch(text)
+	if m == nil {
+		return "", errors.New("no example found in puzzle page")
+	}
+
+	// Examples sometimes highlight parts with <em>
+	return html.UnescapeString(tagRe.ReplaceAllString(m[1], "")), nil
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestClient returns a client pointed at handler, caching into a fresh
+// directory. requests counts the calls that reached the server.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (c *Client, requests *int) {
+	t.Helper()
+	requests = new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	c = &Client{
+		BaseURL:    server.URL,
+		Session:    "abc123",
+		CacheDir:   t.TempDir(),
+		HTTPClient: server.Client(),
+	}
+	return c, requests
+}
+
+func TestInput(t *testing.T) {
+	c, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2025/day/3/input" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "abc123" {
+			t.Errorf("session cookie = %v, %v", cookie, err)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != UserAgent {
+			t.Errorf("User-Agent = %q", ua)
+		}
+		w.Write([]byte("987654321111111\n"))
+	})
+
+	for range 2 {
+		data, err := c.Input(context.Background(), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "987654321111111\n" {
+			t.Fatalf("Input() = %q", data)
+		}
+	}
+	if *requests != 1 {
+		t.Errorf("server got %d requests, want 1 with the second served from the cache", *requests)
+	}
+
+	cached, err := os.ReadFile(filepath.Join(c.CacheDir, "Puzzle3", "input.txt"))
+	if err != nil || string(cached) != "987654321111111\n" {
+		t.Errorf("cached input = %q, %v", cached, err)
+	}
+}
+
+func TestInputNoSession(t *testing.T) {
+	c, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	c.Session = ""
+
+	if _, err := c.Input(context.Background(), 1); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("error = %v, want ErrNoSession", err)
+	}
+	if *requests != 0 {
+		t.Errorf("server got %d requests without a session", *requests)
+	}
+}
+
+func TestInputHTTPError(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+	})
+
+	if _, err := c.Input(context.Background(), 12); err == nil {
+		t.Fatal("Input() didn't fail on a 404")
+	}
+
+	// Nothing, not even a temporary file, should be left in the cache
+	entries, err := os.ReadDir(filepath.Join(c.CacheDir, "Puzzle12"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("cache directory holds %v after a failed download", entries)
+	}
+}
+
+func TestParseExample(t *testing.T) {
+	page, err := os.ReadFile("testdata/puzzle.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseExample(string(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "123 328  51 64 \n 45 64  387 23 \n  6 98  215 314\n*   +   *   +  \n"
+	if got != want {
+		t.Errorf("ParseExample() = %q, want %q", got, want)
+	}
+
+	if _, err := ParseExample("<p>No code here</p>"); err == nil {
+		t.Error("ParseExample() found an example in a page without one")
+	}
+}
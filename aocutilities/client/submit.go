@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	TooHigh
+	TooLow
+	Wrong
+	RateLimited
+	// AlreadySolved is returned when the part has already been completed, or
+	// part 2 is submitted before part 1.
+	AlreadySolved
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "correct"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case Wrong:
+		return "wrong"
+	case RateLimited:
+		return "rate limited"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+// Result is the parsed response to a submitted answer. Wait is how long the
+// site wants us to hold off before the next submission.
+type Result struct {
+	Outcome Outcome
+	Wait    time.Duration
+	Message string
+}
+
+// Submit posts an answer for a day and part. While a previous response's
+// cooldown is still running it returns RateLimited without contacting the
+// site.
+func (c *Client) Submit(ctx context.Context, day, part int, answer string) (Result, error) {
+	if remaining := c.cooldownRemaining(); remaining > 0 {
+		return Result{
+			Outcome: RateLimited,
+			Wait:    remaining,
+			Message: "still cooling down from the last submission",
+		}, nil
+	}
+
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.url(fmt.Sprintf("/%d/day/%d/answer", Year, day)), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("submitting day %d part %d: %w", day, part, err)
+	}
+
+	result, err := ParseResponse(string(body))
+	if err != nil {
+		return result, err
+	}
+
+	if result.Wait > 0 {
+		if err := c.startCooldown(result.Wait); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+	returnRe  = regexp.MustCompile(`\[Return to [^\]]*\]`)
+	leftRe    = regexp.MustCompile(`[Yy]ou have ((?:\d+h\s*)?(?:\d+m\s*)?(?:\d+s)?) left to wait`)
+	minutesRe = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// ParseResponse turns the page returned after submitting an answer into a
+// Result.
+func ParseResponse(page string) (Result, error) {
+	text := page
+	if m := articleRe.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = html.UnescapeString(tagRe.ReplaceAllString(text, ""))
+	text = returnRe.ReplaceAllString(text, "")
+	text = strings.TrimSpace(spaceRe.ReplaceAllString(text, " "))
+
+	result := Result{Message: text}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		result.Outcome = Correct
+	case strings.Contains(text, "answer too recently"):
+		result.Outcome = RateLimited
+	case strings.Contains(text, "right level"):
+		result.Outcome = AlreadySolved
+	case strings.Contains(text, "too high"):
+		result.Outcome = TooHigh
+	case strings.Contains(text, "too low"):
+		result.Outcome = TooLow
+	case strings.Contains(text, "not the right answer"):
+		result.Outcome = Wrong
+	default:
+		return result, fmt.Errorf("unrecognised response: %q", text)
+	}
+
+	if m := leftRe.FindStringSubmatch(text); m != nil {
+		wait, err := time.ParseDuration(strings.ReplaceAll(m[1], " ", ""))
+		if err != nil {
+			return result, fmt.Errorf("parsing wait time %q: %w", m[1], err)
+		}
+		result.Wait = wait
+	} else if m := minutesRe.FindStringSubmatch(text); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		result.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return result, nil
+}
+
+// The cooldown is kept on disk so it holds across separate runs.
+func (c *Client) cooldownPath() string {
+	return filepath.Join(c.CacheDir, ".aoc", "cooldown")
+}
+
+func (c *Client) cooldownRemaining() time.Duration {
+	data, err := os.ReadFile(c.cooldownPath())
+	if err != nil {
+		return 0
+	}
+
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return time.Until(until).Round(time.Second)
+}
+
+func (c *Client) startCooldown(wait time.Duration) error {
+	until := time.Now().Add(wait).Format(time.RFC3339)
+	err := writeFileAtomic(c.cooldownPath(), []byte(until+"\n"))
+	if errors.Is(err, fs.ErrPermission) {
+		// Not being able to remember the cooldown shouldn't lose the result.
+		return nil
+	}
+	return err
+}
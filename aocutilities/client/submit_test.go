@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{
+			"correct",
+			`<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to decorating the North Pole. <a href="/2025/day/1#part2">[Continue to Part Two]</a></p></article></main>`,
+			Correct, 0,
+		},
+		{
+			"too high",
+			`<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2025/about">about page</a>, or you can ask for hints on the <a href="https://www.reddit.com/r/adventofcode/" target="_blank">subreddit</a>.  Please wait one minute before trying again. <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`,
+			TooHigh, time.Minute,
+		},
+		{
+			"too low",
+			`<main><article><p>That's not the right answer; your answer is too low.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`,
+			TooLow, time.Minute,
+		},
+		{
+			"wrong with a longer wait",
+			`<main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.  Because you have guessed incorrectly 4 times on this puzzle, please wait 5 minutes before trying again. <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`,
+			Wrong, 5 * time.Minute,
+		},
+		{
+			"too recently",
+			`<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 32s left to wait. <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`,
+			RateLimited, 4*time.Minute + 32*time.Second,
+		},
+		{
+			"right level",
+			`<main><article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`,
+			AlreadySolved, 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResponse(tt.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Outcome != tt.outcome || got.Wait != tt.wait {
+				t.Errorf("got %v, wait %v; want %v, wait %v", got.Outcome, got.Wait, tt.outcome, tt.wait)
+			}
+			if strings.Contains(got.Message, "<") || strings.Contains(got.Message, "Return to") {
+				t.Errorf("message not cleaned up: %q", got.Message)
+			}
+		})
+	}
+
+	if _, err := ParseResponse("<article><p>Something new</p></article>"); err == nil {
+		t.Error("ParseResponse() accepted an unrecognised page")
+	}
+}
+
+func TestSubmitCooldown(t *testing.T) {
+	c, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2025/day/6/answer" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if level, answer := r.PostForm.Get("level"), r.PostForm.Get("answer"); level != "2" || answer != "3263827" {
+			t.Errorf("posted level %q, answer %q", level, answer)
+		}
+		w.Write([]byte(`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`))
+	})
+
+	result, err := c.Submit(context.Background(), 6, 2, "3263827")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Outcome != TooLow || result.Wait != 5*time.Minute {
+		t.Fatalf("Submit() = %+v", result)
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.CacheDir, ".aoc", "cooldown"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(until); d < 4*time.Minute || d > 5*time.Minute {
+		t.Errorf("cooldown ends in %v, want about 5m", d)
+	}
+
+	// Inside the cooldown the site isn't contacted at all
+	result, err = c.Submit(context.Background(), 6, 2, "3263828")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Outcome != RateLimited || result.Wait <= 0 {
+		t.Errorf("second Submit() = %+v, want RateLimited", result)
+	}
+	if *requests != 1 {
+		t.Errorf("server got %d requests, want 1", *requests)
+	}
+}
@@ -0,0 +1,248 @@
+package aocutilites
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+	"sort"
+)
+
+// Collections
+//
+// Go maps iterate in a random order, so the helpers here that return items
+// come back sorted (or in the order they were first seen) to keep answers
+// and debugging output the same from run to run.
+
+// SortedKeys returns the keys of any map, including a Set, in order.
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := slices.Collect(maps.Keys(m))
+	slices.Sort(keys)
+	return keys
+}
+
+// SortedItems iterates over any map in key order.
+func SortedItems[M ~map[K]V, K cmp.Ordered, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range SortedKeys(m) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Set
+
+type Set[T comparable] map[T]struct{}
+
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+func (s Set[T]) Clone() Set[T] {
+	return maps.Clone(s)
+}
+
+// Union returns the items in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	u := make(Set[T], max(len(s), len(other)))
+	maps.Copy(u, s)
+	maps.Copy(u, other)
+	return u
+}
+
+// Intersection returns the items in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	// Walk the smaller set
+	if len(other) < len(s) {
+		s, other = other, s
+	}
+	i := Set[T]{}
+	for item := range s {
+		if other.Contains(item) {
+			i[item] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference returns the items in s that aren't in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	d := Set[T]{}
+	for item := range s {
+		if !other.Contains(item) {
+			d[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Counter
+//
+// Counter tallies how often each item is seen. The zero value is ready to
+// use. Items are remembered in the order they were first added, which is
+// how ties are broken.
+
+type Counter[T comparable] struct {
+	counts map[T]int
+	order  []T
+	total  int
+}
+
+// Counted is an item and how many times it was seen.
+type Counted[T any] struct {
+	Item  T
+	Count int
+}
+
+func (c *Counter[T]) Add(item T) {
+	c.AddN(item, 1)
+}
+
+func (c *Counter[T]) AddN(item T, n int) {
+	if c.counts == nil {
+		c.counts = map[T]int{}
+	}
+	if _, ok := c.counts[item]; !ok {
+		c.order = append(c.order, item)
+	}
+	c.counts[item] += n
+	c.total += n
+}
+
+// Count returns how many times item was seen, 0 if never.
+func (c *Counter[T]) Count(item T) int {
+	return c.counts[item]
+}
+
+// Len returns the number of different items.
+func (c *Counter[T]) Len() int {
+	return len(c.order)
+}
+
+// Total returns the sum of all the counts.
+func (c *Counter[T]) Total() int {
+	return c.total
+}
+
+// MostCommon returns the n items with the highest counts, highest first,
+// with ties in the order they were first seen. A negative n returns them
+// all.
+func (c *Counter[T]) MostCommon(n int) []Counted[T] {
+	all := make([]Counted[T], len(c.order))
+	for i, item := range c.order {
+		all[i] = Counted[T]{item, c.counts[item]}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Count > all[j].Count
+	})
+
+	if n >= 0 && n < len(all) {
+		all = all[:n]
+	}
+	return all
+}
+
+// All iterates over the items in the order they were first seen.
+func (c *Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for _, item := range c.order {
+			if !yield(item, c.counts[item]) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns a copy of the counts, e.g. for SortedItems.
+func (c *Counter[T]) Map() map[T]int {
+	return maps.Clone(c.counts)
+}
+
+// DefaultMap
+//
+// DefaultMap is a map that makes up a value for keys it hasn't seen, like
+// Python's defaultdict. The zero value is ready to use and gives V's zero
+// value for missing keys.
+
+type DefaultMap[K comparable, V any] struct {
+	m       map[K]V
+	initial func() V
+}
+
+// NewDefaultMap returns a map whose missing keys get initial(). A nil
+// initial gives V's zero value.
+func NewDefaultMap[K comparable, V any](initial func() V) *DefaultMap[K, V] {
+	return &DefaultMap[K, V]{m: map[K]V{}, initial: initial}
+}
+
+// Get returns the value for k, storing a new one first if k is missing.
+func (d *DefaultMap[K, V]) Get(k K) V {
+	if v, ok := d.m[k]; ok {
+		return v
+	}
+	var v V
+	if d.initial != nil {
+		v = d.initial()
+	}
+	d.Set(k, v)
+	return v
+}
+
+func (d *DefaultMap[K, V]) Set(k K, v V) {
+	if d.m == nil {
+		d.m = map[K]V{}
+	}
+	d.m[k] = v
+}
+
+// Update replaces the value for k with f of it, e.g. appending to a slice.
+func (d *DefaultMap[K, V]) Update(k K, f func(V) V) {
+	d.Set(k, f(d.Get(k)))
+}
+
+func (d *DefaultMap[K, V]) Has(k K) bool {
+	_, ok := d.m[k]
+	return ok
+}
+
+func (d *DefaultMap[K, V]) Delete(k K) {
+	delete(d.m, k)
+}
+
+func (d *DefaultMap[K, V]) Len() int {
+	return len(d.m)
+}
+
+// Map returns the underlying map, e.g. for SortedItems. Changes to it show
+// through.
+func (d *DefaultMap[K, V]) Map() map[K]V {
+	if d.m == nil {
+		d.m = map[K]V{}
+	}
+	return d.m
+}
@@ -0,0 +1,115 @@
+package aocutilites
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"intersection", a.Intersection(b), []int{3, 4}},
+		{"difference", a.Difference(b), []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SortedKeys(tt.got); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	c := a.Clone()
+	c.Remove(1, 2)
+	c.Add(9)
+	if !a.Contains(1) || a.Len() != 4 || c.Contains(1) || !c.Contains(9) {
+		t.Fatal("changing a clone changed the original")
+	}
+}
+
+func TestCounter(t *testing.T) {
+	var c Counter[string]
+	for _, word := range []string{"b", "a", "c", "a", "b", "d", "a"} {
+		c.Add(word)
+	}
+	c.AddN("d", 1)
+
+	if c.Count("a") != 3 || c.Count("z") != 0 {
+		t.Fatalf("Count(a) = %d, Count(z) = %d", c.Count("a"), c.Count("z"))
+	}
+	if c.Len() != 4 || c.Total() != 8 {
+		t.Fatalf("Len() = %d, Total() = %d, want 4, 8", c.Len(), c.Total())
+	}
+
+	// b and d tie on 2, and b was seen first
+	want := []Counted[string]{{"a", 3}, {"b", 2}, {"d", 2}}
+	if got := c.MostCommon(3); !slices.Equal(got, want) {
+		t.Fatalf("MostCommon(3) = %v, want %v", got, want)
+	}
+	if got := c.MostCommon(-1); len(got) != 4 || got[3].Item != "c" {
+		t.Fatalf("MostCommon(-1) = %v", got)
+	}
+
+	order := []string{}
+	for item := range c.All() {
+		order = append(order, item)
+	}
+	if !slices.Equal(order, []string{"b", "a", "c", "d"}) {
+		t.Fatalf("All() order = %v", order)
+	}
+
+	keys := []string{}
+	for k, n := range SortedItems(c.Map()) {
+		keys = append(keys, k)
+		if n != c.Count(k) {
+			t.Fatalf("SortedItems gave %s = %d", k, n)
+		}
+	}
+	if !slices.Equal(keys, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("SortedItems order = %v", keys)
+	}
+}
+
+func TestDefaultMap(t *testing.T) {
+	groups := NewDefaultMap[int](func() []string { return []string{} })
+	for _, word := range []string{"go", "aoc", "to", "day"} {
+		groups.Update(len(word), func(ws []string) []string { return append(ws, word) })
+	}
+
+	if got := groups.Get(2); !slices.Equal(got, []string{"go", "to"}) {
+		t.Fatalf("Get(2) = %v", got)
+	}
+	if groups.Has(5) {
+		t.Fatal("Has(5) before it was asked for")
+	}
+	if got := groups.Get(5); got == nil || len(got) != 0 || !groups.Has(5) {
+		t.Fatalf("Get(5) = %v, want a new empty slice stored", got)
+	}
+	if got := SortedKeys(groups.Map()); !slices.Equal(got, []int{2, 3, 5}) {
+		t.Fatalf("keys = %v", got)
+	}
+
+	counts := NewDefaultMap[string, int](nil)
+	counts.Set("x", counts.Get("x")+2)
+	counts.Delete("y")
+	if counts.Get("x") != 2 || counts.Len() != 1 {
+		t.Fatalf("counts = %v", counts.Map())
+	}
+
+	var zero DefaultMap[string, int]
+	if zero.Get("a") != 0 || zero.Len() != 1 {
+		t.Fatalf("zero value after Get = %v", zero.Map())
+	}
+	zero.Update("b", func(n int) int { return n + 1 })
+	if zero.Get("b") != 1 {
+		t.Fatalf("zero value after Update = %v", zero.Map())
+	}
+}
@@ -0,0 +1,47 @@
+package graph
+
+import aocutilites "AOC2025/aocutilities"
+
+// Builders
+
+// FromGrid builds a directed graph over the cells of g. For each cell, and
+// each neighbour in directions that's inside the grid, connect decides
+// whether there's an edge. Every cell is a node, even if nothing connects to
+// it.
+//
+//	walls := graph.FromGrid(grid, aocutilites.Directions4,
+//		func(_, _ aocutilites.Point, _, to rune) bool { return to != '#' })
+func FromGrid[T any](g *aocutilites.Grid[T], directions []aocutilites.Direction, connect func(from, to aocutilites.Point, a, b T) bool) *Graph[aocutilites.Point] {
+	result := NewDirected[aocutilites.Point]()
+
+	for p, a := range g.All() {
+		result.AddNode(p)
+		for _, d := range directions {
+			q := p.Move(d)
+			b, ok := g.At(q)
+			if ok && connect(p, q, a, b) {
+				result.AddEdge(p, q)
+			}
+		}
+	}
+
+	return result
+}
+
+// FromPoints builds an undirected graph over a list of points, checking
+// every pair. connect returns the cost of the edge between a and b, or false
+// for no edge; returning true for every pair gives a complete graph.
+func FromPoints[P comparable](points []P, connect func(a, b P) (int, bool)) *Graph[P] {
+	result := New[P]()
+
+	for i, a := range points {
+		result.AddNode(a)
+		for _, b := range points[i+1:] {
+			if cost, ok := connect(a, b); ok {
+				result.AddWeightedEdge(a, b, cost)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,302 @@
+// Package graph is an adjacency-list graph with the usual traversals.
+//
+// Nodes can be any comparable key, such as a Point or a string. A Graph
+// implements aocutilites.Graph, so it can be handed straight to Dijkstra or
+// AStar.
+package graph
+
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+// ErrCycle is returned by operations that need a directed acyclic graph.
+var ErrCycle = errors.New("graph has a cycle")
+
+type Edge[N comparable] = aocutilites.Edge[N]
+
+// Graph stores nodes in the order they were added, so everything that
+// iterates over it is deterministic.
+type Graph[N comparable] struct {
+	directed bool
+	nodes    []N
+	index    map[N]int
+	adj      [][]Edge[N]
+}
+
+// New returns an empty undirected graph: every edge can be followed both
+// ways.
+func New[N comparable]() *Graph[N] {
+	return &Graph[N]{index: map[N]int{}}
+}
+
+func NewDirected[N comparable]() *Graph[N] {
+	return &Graph[N]{directed: true, index: map[N]int{}}
+}
+
+func (g *Graph[N]) Directed() bool {
+	return g.directed
+}
+
+// AddNode adds n if it isn't already in the graph.
+func (g *Graph[N]) AddNode(n N) {
+	g.id(n)
+}
+
+func (g *Graph[N]) id(n N) int {
+	if i, ok := g.index[n]; ok {
+		return i
+	}
+	i := len(g.nodes)
+	g.index[n] = i
+	g.nodes = append(g.nodes, n)
+	g.adj = append(g.adj, nil)
+	return i
+}
+
+// AddEdge adds an unweighted edge, which costs 1. Nodes are added as needed.
+func (g *Graph[N]) AddEdge(from, to N) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+// AddWeightedEdge adds an edge with the given cost. Adding the same edge
+// twice gives two parallel edges.
+func (g *Graph[N]) AddWeightedEdge(from, to N, cost int) {
+	f, t := g.id(from), g.id(to)
+	g.adj[f] = append(g.adj[f], Edge[N]{To: to, Cost: cost})
+	if !g.directed && f != t {
+		g.adj[t] = append(g.adj[t], Edge[N]{To: from, Cost: cost})
+	}
+}
+
+// Neighbours returns the edges out of n. The slice belongs to the graph and
+// mustn't be changed.
+func (g *Graph[N]) Neighbours(n N) []Edge[N] {
+	i, ok := g.index[n]
+	if !ok {
+		return nil
+	}
+	return g.adj[i]
+}
+
+func (g *Graph[N]) HasNode(n N) bool {
+	_, ok := g.index[n]
+	return ok
+}
+
+// Nodes returns every node in the order it was added.
+func (g *Graph[N]) Nodes() []N {
+	return slices.Clone(g.nodes)
+}
+
+// Len returns the number of nodes.
+func (g *Graph[N]) Len() int {
+	return len(g.nodes)
+}
+
+// BFS visits every node reachable from start, nearest first, with how many
+// edges away it is.
+func (g *Graph[N]) BFS(start N) iter.Seq2[N, int] {
+	return func(yield func(N, int) bool) {
+		s, ok := g.index[start]
+		if !ok {
+			return
+		}
+
+		depth := make([]int, len(g.nodes))
+		for i := range depth {
+			depth[i] = -1
+		}
+		depth[s] = 0
+
+		var queue aocutilites.Queue[int]
+		queue.Push(s)
+		for !queue.IsEmpty() {
+			i, _ := queue.Pop()
+			if !yield(g.nodes[i], depth[i]) {
+				return
+			}
+			for _, e := range g.adj[i] {
+				j := g.index[e.To]
+				if depth[j] < 0 {
+					depth[j] = depth[i] + 1
+					queue.Push(j)
+				}
+			}
+		}
+	}
+}
+
+// DFS visits every node reachable from start, going as deep as it can
+// before backtracking. Neighbours are tried in the order their edges were
+// added.
+func (g *Graph[N]) DFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		s, ok := g.index[start]
+		if !ok {
+			return
+		}
+
+		seen := make([]bool, len(g.nodes))
+
+		var stack aocutilites.Stack[int]
+		stack.Push(s)
+		for !stack.IsEmpty() {
+			i, _ := stack.Pop()
+			if seen[i] {
+				continue
+			}
+			seen[i] = true
+			if !yield(g.nodes[i]) {
+				return
+			}
+
+			// Push in reverse so the first edge is popped first
+			for k := len(g.adj[i]) - 1; k >= 0; k-- {
+				if j := g.index[g.adj[i][k].To]; !seen[j] {
+					stack.Push(j)
+				}
+			}
+		}
+	}
+}
+
+// TopologicalSort returns the nodes so that every edge goes from an earlier
+// node to a later one. It returns ErrCycle if there's no such order, which
+// includes any undirected graph with an edge.
+func (g *Graph[N]) TopologicalSort() ([]N, error) {
+	inDegree := make([]int, len(g.nodes))
+	for _, edges := range g.adj {
+		for _, e := range edges {
+			inDegree[g.index[e.To]]++
+		}
+	}
+
+	var queue aocutilites.Queue[int]
+	for i, d := range inDegree {
+		if d == 0 {
+			queue.Push(i)
+		}
+	}
+
+	order := make([]N, 0, len(g.nodes))
+	for !queue.IsEmpty() {
+		i, _ := queue.Pop()
+		order = append(order, g.nodes[i])
+		for _, e := range g.adj[i] {
+			j := g.index[e.To]
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				queue.Push(j)
+			}
+		}
+	}
+
+	if len(order) != len(g.nodes) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// StronglyConnectedComponents groups nodes that can all reach each other.
+// For an undirected graph these are just the connected components.
+// Components come out in reverse topological order: nothing in a component
+// has an edge into a later one.
+func (g *Graph[N]) StronglyConnectedComponents() [][]N {
+	// Tarjan's algorithm, iterative so big graphs don't blow the stack
+	const unvisited = -1
+	index := make([]int, len(g.nodes))
+	low := make([]int, len(g.nodes))
+	onStack := make([]bool, len(g.nodes))
+	for i := range index {
+		index[i] = unvisited
+	}
+
+	type frame struct{ node, edge int }
+	var (
+		components [][]N
+		stack      []int
+		next       int
+	)
+
+	for root := range g.nodes {
+		if index[root] != unvisited {
+			continue
+		}
+
+		index[root], low[root] = next, next
+		next++
+		stack = append(stack, root)
+		onStack[root] = true
+		calls := []frame{{root, 0}}
+
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			v := f.node
+
+			if f.edge < len(g.adj[v]) {
+				w := g.index[g.adj[v][f.edge].To]
+				f.edge++
+
+				if index[w] == unvisited {
+					index[w], low[w] = next, next
+					next++
+					stack = append(stack, w)
+					onStack[w] = true
+					calls = append(calls, frame{w, 0})
+				} else if onStack[w] {
+					low[v] = min(low[v], index[w])
+				}
+				continue
+			}
+
+			// Done with v: pop a component if v is its root
+			if low[v] == index[v] {
+				component := []N{}
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					component = append(component, g.nodes[w])
+					if w == v {
+						break
+					}
+				}
+				components = append(components, component)
+			}
+
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].node
+				low[parent] = min(low[parent], low[v])
+			}
+		}
+	}
+
+	return components
+}
+
+// CountPaths returns how many different paths lead from one node to another
+// in a directed acyclic graph, or ErrCycle if the graph has a cycle.
+func (g *Graph[N]) CountPaths(from, to N) (int, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return 0, err
+	}
+
+	paths := make(map[N]int, len(order))
+	paths[from] = 1
+	for _, n := range order {
+		if paths[n] == 0 {
+			continue
+		}
+		for _, e := range g.Neighbours(n) {
+			paths[e.To] += paths[n]
+		}
+	}
+
+	return paths[to], nil
+}
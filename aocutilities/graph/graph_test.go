@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+func TestTraversals(t *testing.T) {
+	g := New[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	g.AddNode("lonely")
+
+	depths := map[string]int{}
+	for n, d := range g.BFS("a") {
+		depths[n] = d
+	}
+	want := map[string]int{"a": 0, "b": 1, "c": 1, "d": 2, "e": 3}
+	if len(depths) != len(want) {
+		t.Fatalf("BFS visited %v", depths)
+	}
+	for n, d := range want {
+		if depths[n] != d {
+			t.Errorf("BFS depth of %s = %d, want %d", n, depths[n], d)
+		}
+	}
+
+	// Undirected, so d leads back up to c
+	order := slices.Collect(g.DFS("a"))
+	if wantOrder := []string{"a", "b", "d", "c", "e"}; !slices.Equal(order, wantOrder) {
+		t.Fatalf("DFS order = %v, want %v", order, wantOrder)
+	}
+
+	if n := len(slices.Collect(g.DFS("missing"))); n != 0 {
+		t.Fatalf("DFS from a missing node visited %d nodes", n)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(5, 11)
+	g.AddEdge(7, 11)
+	g.AddEdge(7, 8)
+	g.AddEdge(3, 8)
+	g.AddEdge(3, 10)
+	g.AddEdge(11, 2)
+	g.AddEdge(11, 9)
+	g.AddEdge(11, 10)
+	g.AddEdge(8, 9)
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	position := map[int]int{}
+	for i, n := range order {
+		position[n] = i
+	}
+	for _, from := range g.Nodes() {
+		for _, e := range g.Neighbours(from) {
+			if position[from] > position[e.To] {
+				t.Fatalf("%d comes after %d in %v", from, e.To, order)
+			}
+		}
+	}
+
+	g.AddEdge(9, 7)
+	if _, err := g.TopologicalSort(); !errors.Is(err, ErrCycle) {
+		t.Fatalf("TopologicalSort with a cycle returned %v", err)
+	}
+	if _, err := g.CountPaths(7, 9); !errors.Is(err, ErrCycle) {
+		t.Fatalf("CountPaths with a cycle returned %v", err)
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	g.AddEdge("e", "d")
+	g.AddEdge("e", "f")
+
+	got := [][]string{}
+	for _, c := range g.StronglyConnectedComponents() {
+		slices.Sort(c)
+		got = append(got, c)
+	}
+
+	// Reverse topological order: sinks first
+	want := [][]string{{"f"}, {"d", "e"}, {"a", "b", "c"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("components = %v, want %v", got, want)
+	}
+}
+
+func TestFromGridCountPaths(t *testing.T) {
+	grid, err := aocutilites.ParseGrid([]string{"...", ".#.", "..."}, aocutilites.Runes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only moving right or down makes it a DAG
+	g := FromGrid(grid, []aocutilites.Direction{aocutilites.Right, aocutilites.Down},
+		func(_, _ aocutilites.Point, a, b rune) bool { return a != '#' && b != '#' })
+
+	if g.Len() != 9 {
+		t.Fatalf("Len() = %d, want 9", g.Len())
+	}
+	paths, err := g.CountPaths(aocutilites.Point{Row: 0, Col: 0}, aocutilites.Point{Row: 2, Col: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths != 2 {
+		t.Fatalf("CountPaths = %d, want 2", paths)
+	}
+}
+
+func TestFromPoints(t *testing.T) {
+	points := []int{0, 3, 4, 10}
+
+	// Connect points up to 5 apart, costing the distance between them
+	g := FromPoints(points, func(a, b int) (int, bool) {
+		d := max(a-b, b-a)
+		return d, d <= 5
+	})
+
+	if len(g.Neighbours(4)) != 2 || len(g.Neighbours(10)) != 0 {
+		t.Fatalf("neighbours of 4: %v, of 10: %v", g.Neighbours(4), g.Neighbours(10))
+	}
+
+	paths := aocutilites.Dijkstra[int](g, 0)
+	if d, ok := paths.Dist(4); !ok || d != 4 {
+		t.Fatalf("Dist(4) = %d, %v, want 4", d, ok)
+	}
+	if _, ok := paths.Dist(10); ok {
+		t.Fatal("10 shouldn't be reachable")
+	}
+}
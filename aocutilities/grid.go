@@ -0,0 +1,177 @@
+package aocutilites
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Grid
+//
+// A dense, rectangular grid stored row by row in a single slice. Points use
+// 0-based rows and columns.
+
+type Grid[T any] struct {
+	Width, Height int
+	cells         []T
+}
+
+func NewGrid[T any](width, height int) *Grid[T] {
+	return &Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// ParseGrid builds a grid from lines of text, converting each rune with
+// convert. Every line must be the same length.
+func ParseGrid[T any](lines []string, convert func(rune) T) (*Grid[T], error) {
+	if len(lines) == 0 {
+		return NewGrid[T](0, 0), nil
+	}
+
+	width := len([]rune(lines[0]))
+	g := NewGrid[T](width, len(lines))
+
+	for row, line := range lines {
+		runes := []rune(line)
+		if len(runes) != width {
+			return nil, fmt.Errorf("line %d is %d wide, want %d", row+1, len(runes), width)
+		}
+		for col, r := range runes {
+			g.cells[row*width+col] = convert(r)
+		}
+	}
+
+	return g, nil
+}
+
+// Runes is the convert function for a grid of the characters themselves.
+func Runes(r rune) rune {
+	return r
+}
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < g.Height && p.Col >= 0 && p.Col < g.Width
+}
+
+// Get returns the cell at p. It panics if p is out of bounds.
+func (g *Grid[T]) Get(p Point) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("point %v outside %dx%d grid", p, g.Width, g.Height))
+	}
+	return g.cells[p.Row*g.Width+p.Col]
+}
+
+// At returns the cell at p, or false if p is out of bounds.
+func (g *Grid[T]) At(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.Width+p.Col], true
+}
+
+// Set changes the cell at p. It panics if p is out of bounds.
+func (g *Grid[T]) Set(p Point, value T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("point %v outside %dx%d grid", p, g.Width, g.Height))
+	}
+	g.cells[p.Row*g.Width+p.Col] = value
+}
+
+// Row returns row r. The slice shares the grid's storage.
+func (g *Grid[T]) Row(r int) []T {
+	return g.cells[r*g.Width : (r+1)*g.Width]
+}
+
+// Col returns a copy of column c.
+func (g *Grid[T]) Col(c int) []T {
+	col := make([]T, g.Height)
+	for r := range g.Height {
+		col[r] = g.cells[r*g.Width+c]
+	}
+	return col
+}
+
+// All iterates over every cell, row by row.
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{i / g.Width, i % g.Width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbours4 iterates over the orthogonal neighbours of p that are inside
+// the grid.
+func (g *Grid[T]) Neighbours4(p Point) iter.Seq2[Point, T] {
+	return g.neighbours(p, Directions4)
+}
+
+// Neighbours8 is Neighbours4 with the diagonals as well.
+func (g *Grid[T]) Neighbours8(p Point) iter.Seq2[Point, T] {
+	return g.neighbours(p, Directions8)
+}
+
+func (g *Grid[T]) neighbours(p Point, directions []Direction) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, d := range directions {
+			n := p.Move(d)
+			if !g.InBounds(n) {
+				continue
+			}
+			if !yield(n, g.cells[n.Row*g.Width+n.Col]) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the first cell, row by row, that matches.
+func (g *Grid[T]) Find(match func(T) bool) (Point, bool) {
+	for p, v := range g.All() {
+		if match(v) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every cell that matches, row by row.
+func (g *Grid[T]) FindAll(match func(T) bool) []Point {
+	points := []Point{}
+	for p, v := range g.All() {
+		if match(v) {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// Equal is a match function for Find and FindAll, e.g.
+//
+//	start, ok := g.Find(aocutilites.Equal('S'))
+func Equal[T comparable](value T) func(T) bool {
+	return func(v T) bool {
+		return v == value
+	}
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{Width: g.Width, Height: g.Height, cells: cells}
+}
+
+// Render draws the grid as text, one line per row, using format to choose
+// each cell's character.
+func (g *Grid[T]) Render(format func(T) rune) string {
+	var sb strings.Builder
+	for r := range g.Height {
+		for _, v := range g.Row(r) {
+			sb.WriteRune(format(v))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+package aocutilites
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseGrid(t *testing.T) {
+	g, err := ParseGrid([]string{"S.#", "..^", "#.."}, Runes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Width != 3 || g.Height != 3 {
+		t.Fatalf("size = %dx%d, want 3x3", g.Width, g.Height)
+	}
+	if got := g.Get(Point{1, 2}); got != '^' {
+		t.Fatalf("Get(1,2) = %q, want '^'", got)
+	}
+	if _, ok := g.At(Point{3, 0}); ok {
+		t.Fatal("At outside the grid returned ok")
+	}
+	if got := string(g.Row(1)); got != "..^" {
+		t.Fatalf("Row(1) = %q", got)
+	}
+	if got := string(g.Col(2)); got != "#^." {
+		t.Fatalf("Col(2) = %q", got)
+	}
+
+	if start, ok := g.Find(Equal('S')); !ok || start != (Point{0, 0}) {
+		t.Fatalf("Find(S) = %v, %v", start, ok)
+	}
+	if walls := g.FindAll(Equal('#')); !slices.Equal(walls, []Point{{0, 2}, {2, 0}}) {
+		t.Fatalf("FindAll(#) = %v", walls)
+	}
+
+	if _, err := ParseGrid([]string{"...", ".."}, Runes); err == nil {
+		t.Fatal("ragged lines didn't return an error")
+	}
+}
+
+func TestGridNeighbours(t *testing.T) {
+	g := NewGrid[int](3, 3)
+
+	tests := []struct {
+		name  string
+		p     Point
+		want4 int
+		want8 int
+	}{
+		{"corner", Point{0, 0}, 2, 3},
+		{"edge", Point{0, 1}, 3, 5},
+		{"middle", Point{1, 1}, 4, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n4, n8 := 0, 0
+			for p := range g.Neighbours4(tt.p) {
+				if !g.InBounds(p) {
+					t.Fatalf("neighbour %v is outside the grid", p)
+				}
+				n4++
+			}
+			for range g.Neighbours8(tt.p) {
+				n8++
+			}
+			if n4 != tt.want4 || n8 != tt.want8 {
+				t.Fatalf("got %d and %d neighbours, want %d and %d", n4, n8, tt.want4, tt.want8)
+			}
+		})
+	}
+}
+
+func TestGridCloneAndRender(t *testing.T) {
+	g, _ := ParseGrid([]string{".@", "@."}, func(r rune) bool { return r == '@' })
+
+	c := g.Clone()
+	c.Set(Point{0, 0}, true)
+	if g.Get(Point{0, 0}) {
+		t.Fatal("Set on a clone changed the original")
+	}
+
+	render := func(b bool) rune {
+		if b {
+			return '@'
+		}
+		return '.'
+	}
+	if got := c.Render(render); got != "@@\n@.\n" {
+		t.Fatalf("Render() = %q", got)
+	}
+}
+
+func TestDirection(t *testing.T) {
+	for _, d := range Directions8 {
+		if d.TurnRight().TurnLeft() != d {
+			t.Fatalf("%v turned right then left isn't itself", d)
+		}
+		if d.Delta().Add(d.Opposite().Delta()) != (Point{}) {
+			t.Fatalf("%v and its opposite don't cancel out", d)
+		}
+	}
+
+	if Up.TurnRight() != Right || Left.TurnRight() != Up {
+		t.Fatal("TurnRight isn't clockwise")
+	}
+	if p := (Point{2, 2}).Move(UpLeft); p != (Point{1, 1}) {
+		t.Fatalf("Move(UpLeft) = %v", p)
+	}
+	if d := (Point{0, 0}).Manhattan(Point{-2, 3}); d != 5 {
+		t.Fatalf("Manhattan = %d, want 5", d)
+	}
+}
@@ -0,0 +1,124 @@
+package aocutilites
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Input reading
+
+// Some inputs are a single very long line, so allow for more than
+// bufio.Scanner's default 64KB per line.
+const maxLineLength = 1024 * 1024
+
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	return scanner
+}
+
+// ReadLines returns every line of the input without line endings.
+func ReadLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+
+	scanner := newScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// ReadGrid returns the input as rows of runes.
+func ReadGrid(r io.Reader) ([][]rune, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := make([][]rune, len(lines))
+	for i, line := range lines {
+		grid[i] = []rune(line)
+	}
+	return grid, nil
+}
+
+// A '-' is only a sign if it doesn't follow a digit, so "3-5" is a range
+var intRe = regexp.MustCompile(`(?:^|\D)(-?\d+)`)
+
+// ReadInts returns all the integers on each line, ignoring anything between
+// them. A '-' directly before a number makes it negative, unless it comes
+// straight after another number: "3-5" reads as 3 and 5, "-3--5" as -3
+// and -5.
+func ReadInts(r io.Reader) ([][]int, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([][]int, len(lines))
+	for i, line := range lines {
+		nums := []int{}
+		for _, m := range intRe.FindAllStringSubmatch(line, -1) {
+			num, err := strconv.Atoi(m[1])
+			if err != nil {
+				return nil, err
+			}
+			nums = append(nums, num)
+		}
+		output[i] = nums
+	}
+	return output, nil
+}
+
+// ReadSections splits the input into groups of lines separated by blank
+// lines. Runs of blank lines count as one separator.
+func ReadSections(r io.Reader) ([][]string, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := [][]string{}
+	section := []string{}
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(section) > 0 {
+				sections = append(sections, section)
+				section = []string{}
+			}
+			continue
+		}
+		section = append(section, line)
+	}
+	if len(section) > 0 {
+		sections = append(sections, section)
+	}
+
+	return sections, nil
+}
+
+// ReadRecords splits the whole input on sep, e.g. "," for inputs that are one
+// long comma-separated line. Line breaks are ignored, each record is trimmed
+// and empty records are dropped.
+func ReadRecords(r io.Reader, sep string) ([]string, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []string{}
+	for _, record := range strings.Split(strings.Join(lines, ""), sep) {
+		record = strings.TrimSpace(record)
+		if record != "" {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
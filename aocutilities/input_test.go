@@ -0,0 +1,158 @@
+package aocutilites
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"empty", "", []string{}},
+		{"no trailing newline", "a\nb", []string{"a", "b"}},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"crlf", "a\r\nb\r\n", []string{"a", "b"}},
+		{"blank lines kept", "a\n\nb\n", []string{"a", "", "b"}},
+		{"spaces kept", " a \n", []string{" a "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadLines(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLinesLongLine(t *testing.T) {
+	line := strings.Repeat("x", 200*1024)
+
+	got, err := ReadLines(strings.NewReader(line + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != line {
+		t.Errorf("ReadLines() lost the long line")
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestReadersReturnErrors(t *testing.T) {
+	readers := map[string]func() error{
+		"ReadLines":    func() error { _, err := ReadLines(failingReader{}); return err },
+		"ReadGrid":     func() error { _, err := ReadGrid(failingReader{}); return err },
+		"ReadInts":     func() error { _, err := ReadInts(failingReader{}); return err },
+		"ReadSections": func() error { _, err := ReadSections(failingReader{}); return err },
+		"ReadRecords":  func() error { _, err := ReadRecords(failingReader{}, ","); return err },
+	}
+
+	for name, read := range readers {
+		if err := read(); err == nil {
+			t.Errorf("%s didn't return the read error", name)
+		}
+	}
+}
+
+func TestReadGrid(t *testing.T) {
+	got, err := ReadGrid(strings.NewReader("ab\n.é\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]rune{{'a', 'b'}, {'.', 'é'}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadGrid() = %q, want %q", got, want)
+	}
+}
+
+func TestReadInts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]int
+	}{
+		{"csv", "162,817,812\n57,618,57\n", [][]int{{162, 817, 812}, {57, 618, 57}}},
+		{"ranges", "3-5\n", [][]int{{3, 5}}},
+		{"negative ranges", "-3--5,1-2-3\n", [][]int{{-3, -5, 1, 2, 3}}},
+		{"words", "move 1 from 2 to -3\n", [][]int{{1, 2, -3}}},
+		{"no numbers", "abc\n", [][]int{{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadInts(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadInts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSections(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]string
+	}{
+		{"two sections", "3-5\n10-14\n\n1\n5\n", [][]string{{"3-5", "10-14"}, {"1", "5"}}},
+		{"repeated blank lines", "a\n\n\n\nb\n", [][]string{{"a"}, {"b"}}},
+		{"leading and trailing blanks", "\na\n\n", [][]string{{"a"}}},
+		{"whitespace-only separator", "a\n  \nb", [][]string{{"a"}, {"b"}}},
+		{"empty", "", [][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadSections(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadSections() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRecords(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"one line", "11-22,95-115\n", []string{"11-22", "95-115"}},
+		{"wrapped", "11-22,95-\n115\n", []string{"11-22", "95-115"}},
+		{"trailing separator", "a,b,\n", []string{"a", "b"}},
+		{"spaces", " a , b ", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadRecords(strings.NewReader(tt.input), ",")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadRecords() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package aocutilites
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Intervals
+//
+// Interval is an inclusive range of integers, like the "3-5" ranges in a lot
+// of puzzles. IntervalSet keeps a set of them sorted and non-overlapping, so
+// lookups are a binary search and the covered length is a simple sum.
+
+type Interval struct {
+	Start, End int
+}
+
+// Len returns how many integers the interval covers.
+func (iv Interval) Len() int {
+	if iv.End < iv.Start {
+		return 0
+	}
+	return iv.End - iv.Start + 1
+}
+
+func (iv Interval) Contains(x int) bool {
+	return x >= iv.Start && x <= iv.End
+}
+
+func (iv Interval) String() string {
+	return fmt.Sprintf("%d-%d", iv.Start, iv.End)
+}
+
+// IntervalSet is a set of integers stored as sorted, disjoint intervals. The
+// zero value is an empty set that merges overlapping intervals. Set
+// MergeAdjacent to also merge intervals that touch, e.g. 3-5 and 6-8 into 3-8;
+// it doesn't change which integers are in the set, only how they're grouped.
+type IntervalSet struct {
+	MergeAdjacent bool
+	intervals     []Interval
+}
+
+func NewIntervalSet(intervals ...Interval) *IntervalSet {
+	s := &IntervalSet{}
+	for _, iv := range intervals {
+		s.Insert(iv)
+	}
+	return s
+}
+
+// gap is how far apart two intervals can be and still be merged.
+func (s *IntervalSet) gap() int {
+	if s.MergeAdjacent {
+		return 1
+	}
+	return 0
+}
+
+// Insert adds iv to the set, merging it with any intervals it overlaps.
+// Empty intervals (End < Start) are ignored.
+func (s *IntervalSet) Insert(iv Interval) {
+	if iv.End < iv.Start {
+		return
+	}
+	g := s.gap()
+
+	// Intervals i to j-1 overlap (or touch) iv and get merged into it
+	i := sort.Search(len(s.intervals), func(k int) bool {
+		return s.intervals[k].End+g >= iv.Start
+	})
+	j := i
+	for j < len(s.intervals) && s.intervals[j].Start-g <= iv.End {
+		j++
+	}
+
+	if i < j {
+		iv.Start = min(iv.Start, s.intervals[i].Start)
+		iv.End = max(iv.End, s.intervals[j-1].End)
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, iv)
+}
+
+// Remove takes every integer in iv out of the set, splitting intervals if
+// needed.
+func (s *IntervalSet) Remove(iv Interval) {
+	if iv.End < iv.Start {
+		return
+	}
+
+	i := sort.Search(len(s.intervals), func(k int) bool {
+		return s.intervals[k].End >= iv.Start
+	})
+	j := i
+	for j < len(s.intervals) && s.intervals[j].Start <= iv.End {
+		j++
+	}
+	if i == j {
+		return
+	}
+
+	// Keep whatever sticks out either side of iv
+	pieces := make([]Interval, 0, 2)
+	if first := s.intervals[i]; first.Start < iv.Start {
+		pieces = append(pieces, Interval{first.Start, iv.Start - 1})
+	}
+	if last := s.intervals[j-1]; last.End > iv.End {
+		pieces = append(pieces, Interval{iv.End + 1, last.End})
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, pieces...)
+}
+
+// Contains reports whether x is in the set, in O(log n).
+func (s *IntervalSet) Contains(x int) bool {
+	i := sort.Search(len(s.intervals), func(k int) bool {
+		return s.intervals[k].End >= x
+	})
+	return i < len(s.intervals) && s.intervals[i].Start <= x
+}
+
+// Intervals returns a copy of the set's intervals in order.
+func (s *IntervalSet) Intervals() []Interval {
+	return slices.Clone(s.intervals)
+}
+
+// Len returns the number of separate intervals.
+func (s *IntervalSet) Len() int {
+	return len(s.intervals)
+}
+
+// TotalLength returns how many integers the set covers.
+func (s *IntervalSet) TotalLength() int {
+	total := 0
+	for _, iv := range s.intervals {
+		total += iv.Len()
+	}
+	return total
+}
+
+func (s *IntervalSet) Clone() *IntervalSet {
+	return &IntervalSet{MergeAdjacent: s.MergeAdjacent, intervals: slices.Clone(s.intervals)}
+}
+
+// Union returns the integers in either set.
+func (s *IntervalSet) Union(other *IntervalSet) *IntervalSet {
+	u := s.Clone()
+	for _, iv := range other.intervals {
+		u.Insert(iv)
+	}
+	return u
+}
+
+// Intersection returns the integers in both sets.
+func (s *IntervalSet) Intersection(other *IntervalSet) *IntervalSet {
+	result := &IntervalSet{MergeAdjacent: s.MergeAdjacent}
+
+	// Both lists are sorted, so walk them together
+	a, b := s.intervals, other.intervals
+	for len(a) > 0 && len(b) > 0 {
+		start := max(a[0].Start, b[0].Start)
+		end := min(a[0].End, b[0].End)
+		if start <= end {
+			result.Insert(Interval{start, end})
+		}
+
+		if a[0].End < b[0].End {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+
+	return result
+}
+
+// Difference returns the integers in s that aren't in other.
+func (s *IntervalSet) Difference(other *IntervalSet) *IntervalSet {
+	d := s.Clone()
+	for _, iv := range other.intervals {
+		d.Remove(iv)
+	}
+	return d
+}
+
+// Complement returns the integers within bounds that aren't in the set.
+func (s *IntervalSet) Complement(bounds Interval) *IntervalSet {
+	result := &IntervalSet{MergeAdjacent: s.MergeAdjacent}
+	result.Insert(bounds)
+	for _, iv := range s.intervals {
+		result.Remove(iv)
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package aocutilites
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIntervalSetInsert(t *testing.T) {
+	tests := []struct {
+		name          string
+		mergeAdjacent bool
+		insert        []Interval
+		want          []Interval
+	}{
+		{"empty", false, nil, []Interval{}},
+		{"unsorted overlaps", false, []Interval{{3, 5}, {10, 14}, {16, 20}, {12, 18}}, []Interval{{3, 5}, {10, 20}}},
+		{"contained", false, []Interval{{1, 10}, {3, 4}}, []Interval{{1, 10}}},
+		{"bridges several", false, []Interval{{1, 2}, {5, 6}, {9, 10}, {2, 9}}, []Interval{{1, 10}}},
+		{"adjacent kept apart", false, []Interval{{3, 5}, {6, 8}}, []Interval{{3, 5}, {6, 8}}},
+		{"adjacent merged", true, []Interval{{3, 5}, {6, 8}, {10, 12}}, []Interval{{3, 8}, {10, 12}}},
+		{"empty interval ignored", false, []Interval{{5, 3}}, []Interval{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &IntervalSet{MergeAdjacent: tt.mergeAdjacent}
+			for _, iv := range tt.insert {
+				s.Insert(iv)
+			}
+			if got := s.Intervals(); !slices.Equal(got, tt.want) {
+				t.Fatalf("Intervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervalSetContains(t *testing.T) {
+	s := NewIntervalSet(Interval{3, 5}, Interval{10, 20})
+
+	for _, x := range []int{3, 4, 5, 10, 17, 20} {
+		if !s.Contains(x) {
+			t.Errorf("Contains(%d) = false", x)
+		}
+	}
+	for _, x := range []int{-1, 2, 6, 9, 21} {
+		if s.Contains(x) {
+			t.Errorf("Contains(%d) = true", x)
+		}
+	}
+	if s.TotalLength() != 14 {
+		t.Fatalf("TotalLength() = %d, want 14", s.TotalLength())
+	}
+}
+
+func TestIntervalSetRemove(t *testing.T) {
+	s := NewIntervalSet(Interval{1, 10}, Interval{20, 30})
+
+	s.Remove(Interval{4, 6})
+	s.Remove(Interval{9, 22})
+	s.Remove(Interval{40, 50})
+
+	want := []Interval{{1, 3}, {7, 8}, {23, 30}}
+	if got := s.Intervals(); !slices.Equal(got, want) {
+		t.Fatalf("Intervals() = %v, want %v", got, want)
+	}
+}
+
+func TestIntervalSetOperations(t *testing.T) {
+	a := NewIntervalSet(Interval{1, 10}, Interval{20, 30})
+	b := NewIntervalSet(Interval{5, 25}, Interval{28, 40})
+
+	tests := []struct {
+		name string
+		got  *IntervalSet
+		want []Interval
+	}{
+		{"union", a.Union(b), []Interval{{1, 40}}},
+		{"intersection", a.Intersection(b), []Interval{{5, 10}, {20, 25}, {28, 30}}},
+		{"difference", a.Difference(b), []Interval{{1, 4}, {26, 27}}},
+		{"complement", a.Complement(Interval{0, 35}), []Interval{{0, 0}, {11, 19}, {31, 35}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Intervals(); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if a.TotalLength() != 21 || b.TotalLength() != 34 {
+		t.Fatal("set operations changed their inputs")
+	}
+}
@@ -0,0 +1,218 @@
+package aocutilites
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Integer maths
+//
+// Everything here stays in int, so nothing is lost going through float64
+// the way int(math.Pow(10, n)) loses precision for big n. Results that might
+// not fit come back with an ok flag.
+
+// PowersOfTen holds every power of ten that fits in an int64.
+var PowersOfTen = func() [19]int {
+	var table [19]int
+	table[0] = 1
+	for i := 1; i < len(table); i++ {
+		table[i] = table[i-1] * 10
+	}
+	return table
+}()
+
+// Pow10 returns 10^n, or false if it doesn't fit in an int.
+func Pow10(n int) (int, bool) {
+	if n < 0 || n >= len(PowersOfTen) {
+		return 0, false
+	}
+	return PowersOfTen[n], true
+}
+
+// Pow returns base^exp, or false if it overflows. It panics if exp is
+// negative.
+func Pow(base, exp int) (int, bool) {
+	if exp < 0 {
+		panic("aocutilites: negative exponent")
+	}
+
+	// Exponentiation by squaring
+	result := 1
+	for exp > 0 {
+		var ok bool
+		if exp&1 == 1 {
+			if result, ok = CheckedMul(result, base); !ok {
+				return 0, false
+			}
+		}
+		exp >>= 1
+		if exp > 0 {
+			if base, ok = CheckedMul(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+	return result, true
+}
+
+// CountDigits returns how many decimal digits n has, ignoring its sign.
+func CountDigits(n int) int {
+	count := 1
+	for n >= 10 || n <= -10 {
+		n /= 10
+		count++
+	}
+	return count
+}
+
+// CheckedAdd returns a + b, or false if it overflows.
+func CheckedAdd(a, b int) (int, bool) {
+	sum := a + b
+	// Overflow happened if both operands have the same sign and the sum
+	// doesn't
+	if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// CheckedSub returns a - b, or false if it overflows.
+func CheckedSub(a, b int) (int, bool) {
+	diff := a - b
+	// Overflow happened if the operands have different signs and the
+	// difference doesn't have a's
+	if (a >= 0) != (b >= 0) && (diff >= 0) != (a >= 0) {
+		return 0, false
+	}
+	return diff, true
+}
+
+// CheckedMul returns a * b, or false if it overflows.
+func CheckedMul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	// MinInt / -1 wraps back to MinInt, so the division check misses it
+	if product/b != a || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return product, true
+}
+
+// GCD returns the greatest common divisor of nums, which is always
+// non-negative. GCD() is 0.
+func GCD(nums ...int) int {
+	result := 0
+	for _, n := range nums {
+		a, b := abs(result), abs(n)
+		for b != 0 {
+			a, b = b, a%b
+		}
+		result = a
+	}
+	return result
+}
+
+// LCM returns the lowest common multiple of nums. It doesn't check for
+// overflow; LCM() is 1.
+func LCM(nums ...int) int {
+	result := 1
+	for _, n := range nums {
+		if n == 0 {
+			return 0
+		}
+		result = abs(result / GCD(result, n) * n)
+	}
+	return result
+}
+
+// Mod returns a mod m in the range [0, m), unlike % which keeps a's sign.
+func Mod(a, m int) int {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// MulMod returns a * b mod m without overflowing, for any int-sized m.
+func MulMod(a, b, m int) int {
+	a, b = Mod(a, m), Mod(b, m)
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// ModPow returns base^exp mod m. exp must not be negative.
+func ModPow(base, exp, m int) int {
+	if exp < 0 {
+		panic("aocutilites: negative exponent")
+	}
+
+	result := 1 % m
+	base = Mod(base, m)
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = MulMod(result, base, m)
+		}
+		base = MulMod(base, base, m)
+		exp >>= 1
+	}
+	return result
+}
+
+// ModInverse returns x such that a * x = 1 mod m, or false if a and m aren't
+// coprime.
+func ModInverse(a, m int) (int, bool) {
+	g, x, _ := extendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// extendedGCD returns g = gcd(a, b) and x, y with a*x + b*y = g.
+func extendedGCD(a, b int) (g, x, y int) {
+	x0, x1, y0, y1 := 1, 0, 0, 1
+	for b != 0 {
+		q := a / b
+		a, b = b, a-q*b
+		x0, x1 = x1, x0-q*x1
+		y0, y1 = y1, y0-q*y1
+	}
+	return a, x0, y0
+}
+
+// CRT solves x = remainders[i] mod moduli[i] for every i, using the Chinese
+// remainder theorem. The moduli don't have to be coprime. It returns the
+// smallest non-negative x and the combined modulus, or false if there's no
+// solution or the combined modulus overflows.
+func CRT(remainders, moduli []int) (x, m int, ok bool) {
+	if len(remainders) != len(moduli) {
+		panic("aocutilites: CRT needs a modulus for every remainder")
+	}
+
+	x, m = 0, 1
+	for i, mi := range moduli {
+		ri := Mod(remainders[i], mi)
+
+		// Solve x + m*k = ri mod mi for k
+		g, p, _ := extendedGCD(m, mi)
+		if (ri-x)%g != 0 {
+			return 0, 0, false
+		}
+
+		step := mi / g
+		k := MulMod((ri-x)/g, p, step)
+
+		lcm, ok := CheckedMul(m, step)
+		if !ok {
+			return 0, 0, false
+		}
+		// Both terms are below lcm, so their sum fits in a uint64
+		x = int((uint64(x) + uint64(MulMod(m, k, lcm))) % uint64(lcm))
+		m = lcm
+	}
+
+	return x, m, true
+}
@@ -0,0 +1,127 @@
+package aocutilites
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		base, exp int
+		want      int
+		ok        bool
+	}{
+		{2, 10, 1024, true},
+		{-3, 3, -27, true},
+		{7, 0, 1, true},
+		{10, 18, 1_000_000_000_000_000_000, true},
+		{10, 19, 0, false},
+		{2, 63, 0, false},
+		{-2, 63, math.MinInt, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := Pow(tt.base, tt.exp)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Pow(%d, %d) = %d, %v, want %d, %v", tt.base, tt.exp, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	for n := range PowersOfTen {
+		if p, _ := Pow10(n); p != PowersOfTen[n] || CountDigits(p) != n+1 {
+			t.Fatalf("Pow10(%d) = %d", n, p)
+		}
+	}
+	if _, ok := Pow10(19); ok {
+		t.Fatal("Pow10(19) should overflow")
+	}
+	if CountDigits(0) != 1 || CountDigits(-123) != 3 || CountDigits(math.MaxInt) != 19 {
+		t.Fatal("CountDigits is wrong")
+	}
+}
+
+func TestChecked(t *testing.T) {
+	if _, ok := CheckedAdd(math.MaxInt, 1); ok {
+		t.Error("MaxInt + 1 didn't overflow")
+	}
+	if _, ok := CheckedAdd(math.MinInt, -1); ok {
+		t.Error("MinInt - 1 didn't overflow")
+	}
+	if s, ok := CheckedAdd(math.MaxInt, math.MinInt); !ok || s != -1 {
+		t.Errorf("MaxInt + MinInt = %d, %v", s, ok)
+	}
+
+	if _, ok := CheckedMul(math.MinInt, -1); ok {
+		t.Error("MinInt * -1 didn't overflow")
+	}
+	if _, ok := CheckedMul(1<<32, 1<<31); ok {
+		t.Error("2^63 didn't overflow")
+	}
+	if p, ok := CheckedMul(-(1 << 32), 1<<31); !ok || p != math.MinInt {
+		t.Errorf("-2^63 = %d, %v", p, ok)
+	}
+}
+
+func TestGCDLCM(t *testing.T) {
+	if g := GCD(12, -18, 30); g != 6 {
+		t.Errorf("GCD = %d, want 6", g)
+	}
+	if g := GCD(); g != 0 {
+		t.Errorf("GCD() = %d, want 0", g)
+	}
+	if l := LCM(4, 6, 10); l != 60 {
+		t.Errorf("LCM = %d, want 60", l)
+	}
+	if l := LCM(3, 0); l != 0 {
+		t.Errorf("LCM with 0 = %d, want 0", l)
+	}
+}
+
+func TestModular(t *testing.T) {
+	if m := Mod(-7, 3); m != 2 {
+		t.Errorf("Mod(-7, 3) = %d, want 2", m)
+	}
+
+	// Both of these overflow an int64 if multiplied directly
+	const big = 1_000_000_000_000_000_003
+	if got := MulMod(big-1, big-1, big); got != 1 {
+		t.Errorf("MulMod(-1, -1) = %d, want 1", got)
+	}
+	if got := ModPow(2, 10, 1000); got != 24 {
+		t.Errorf("ModPow(2, 10, 1000) = %d, want 24", got)
+	}
+	if got := ModPow(5, big-1, big); got != 1 {
+		t.Errorf("Fermat: ModPow(5, p-1, p) = %d, want 1", got)
+	}
+
+	if inv, ok := ModInverse(3, 11); !ok || inv != 4 {
+		t.Errorf("ModInverse(3, 11) = %d, %v, want 4", inv, ok)
+	}
+	if _, ok := ModInverse(4, 8); ok {
+		t.Error("ModInverse(4, 8) shouldn't exist")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name       string
+		remainders []int
+		moduli     []int
+		x, m       int
+		ok         bool
+	}{
+		{"coprime", []int{2, 3, 2}, []int{3, 5, 7}, 23, 105, true},
+		{"negative remainder", []int{-1, 0}, []int{4, 3}, 3, 12, true},
+		{"shared factor", []int{2, 4}, []int{6, 8}, 20, 24, true},
+		{"no solution", []int{1, 2}, []int{4, 6}, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, m, ok := CRT(tt.remainders, tt.moduli)
+			if x != tt.x || m != tt.m || ok != tt.ok {
+				t.Fatalf("CRT = %d, %d, %v, want %d, %d, %v", x, m, ok, tt.x, tt.m, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package aocutilites
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// Memoization
+//
+// Memoize caches a recursive function. The function is handed a recurse
+// callback to use instead of calling itself, so the recursive calls hit the
+// cache too. Keys can be any comparable type; a struct makes a key from
+// several arguments:
+//
+//	type key struct {
+//		pos   aocutilites.Point
+//		steps int
+//	}
+//
+//	paths := aocutilites.Memoize(func(recurse func(key) int, k key) int {
+//		if k.steps == 0 {
+//			return 1
+//		}
+//		...
+//		return recurse(key{next, k.steps - 1}) + ...
+//	})
+//	total := paths.Get(key{start, 10})
+
+// MemoStats counts cache lookups. Evictions only happen in bounded mode.
+type MemoStats struct {
+	Hits, Misses, Evictions int
+	Size                    int
+}
+
+func (s MemoStats) String() string {
+	rate := 0.0
+	if total := s.Hits + s.Misses; total > 0 {
+		rate = 100 * float64(s.Hits) / float64(total)
+	}
+	return fmt.Sprintf("%d hits, %d misses (%.1f%% hit rate), %d evictions, %d cached",
+		s.Hits, s.Misses, rate, s.Evictions, s.Size)
+}
+
+// memoCache is the storage shared by Memo and SyncMemo. Unbounded it's a
+// plain map; bounded it also keeps a least-recently-used list.
+type memoCache[K comparable, V any] struct {
+	limit   int
+	values  map[K]V
+	entries map[K]*list.Element
+	lru     *list.List
+	stats   MemoStats
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newMemoCache[K comparable, V any](limit int) memoCache[K, V] {
+	if limit < 0 {
+		panic("aocutilites: memo limit must not be negative")
+	}
+	if limit == 0 {
+		return memoCache[K, V]{values: map[K]V{}}
+	}
+	return memoCache[K, V]{limit: limit, entries: map[K]*list.Element{}, lru: list.New()}
+}
+
+func (c *memoCache[K, V]) get(key K) (V, bool) {
+	if c.limit == 0 {
+		v, ok := c.values[key]
+		return v, ok
+	}
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *memoCache[K, V]) put(key K, value V) {
+	if c.limit == 0 {
+		c.values[key] = value
+		c.stats.Size = len(c.values)
+		return
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.lru.MoveToFront(e)
+		return
+	}
+	if c.lru.Len() >= c.limit {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+		c.stats.Evictions++
+	}
+	c.entries[key] = c.lru.PushFront(&lruEntry[K, V]{key, value})
+	c.stats.Size = c.lru.Len()
+}
+
+func (c *memoCache[K, V]) reset() {
+	*c = newMemoCache[K, V](c.limit)
+}
+
+// Memo is a memoized function. It isn't safe for concurrent use; see
+// SyncMemo.
+type Memo[K comparable, V any] struct {
+	f       func(recurse func(K) V, key K) V
+	recurse func(K) V
+	cache   memoCache[K, V]
+}
+
+// Memoize caches every result of f.
+func Memoize[K comparable, V any](f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return MemoizeBounded(0, f)
+}
+
+// MemoizeBounded keeps at most limit results, dropping the least recently
+// used. A limit of 0 means no limit.
+func MemoizeBounded[K comparable, V any](limit int, f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	m := &Memo[K, V]{f: f, cache: newMemoCache[K, V](limit)}
+	m.recurse = m.Get
+	return m
+}
+
+// Get returns f(key), from the cache if it's there.
+func (m *Memo[K, V]) Get(key K) V {
+	if v, ok := m.cache.get(key); ok {
+		m.cache.stats.Hits++
+		return v
+	}
+	m.cache.stats.Misses++
+
+	v := m.f(m.recurse, key)
+	m.cache.put(key, v)
+	return v
+}
+
+func (m *Memo[K, V]) Stats() MemoStats {
+	return m.cache.stats
+}
+
+// Reset empties the cache and the stats.
+func (m *Memo[K, V]) Reset() {
+	m.cache.reset()
+}
+
+// SyncMemo is a Memo that can be shared between goroutines. If a key is
+// already being worked out, other callers wait for that result rather than
+// computing it again, so a wait counts as a hit. If f panics, the panic goes
+// to the caller that ran it and anyone waiting tries again themselves.
+type SyncMemo[K comparable, V any] struct {
+	f       func(recurse func(K) V, key K) V
+	recurse func(K) V
+
+	mu    sync.Mutex
+	cache memoCache[K, V]
+	calls map[K]*memoCall[V]
+}
+
+type memoCall[V any] struct {
+	done  chan struct{}
+	value V
+	ok    bool // false if f panicked
+}
+
+func MemoizeSync[K comparable, V any](f func(recurse func(K) V, key K) V) *SyncMemo[K, V] {
+	return MemoizeSyncBounded(0, f)
+}
+
+func MemoizeSyncBounded[K comparable, V any](limit int, f func(recurse func(K) V, key K) V) *SyncMemo[K, V] {
+	m := &SyncMemo[K, V]{f: f, cache: newMemoCache[K, V](limit), calls: map[K]*memoCall[V]{}}
+	m.recurse = m.Get
+	return m
+}
+
+func (m *SyncMemo[K, V]) Get(key K) V {
+	for {
+		m.mu.Lock()
+		if v, ok := m.cache.get(key); ok {
+			m.cache.stats.Hits++
+			m.mu.Unlock()
+			return v
+		}
+		if call, ok := m.calls[key]; ok {
+			m.cache.stats.Hits++
+			m.mu.Unlock()
+			<-call.done
+			if call.ok {
+				return call.value
+			}
+			continue
+		}
+		call := &memoCall[V]{done: make(chan struct{})}
+		m.calls[key] = call
+		m.cache.stats.Misses++
+		m.mu.Unlock()
+
+		return m.compute(key, call)
+	}
+}
+
+// compute runs f for key without holding the lock, so the recursive calls
+// can take it. Waiters are always released, even if f panics.
+func (m *SyncMemo[K, V]) compute(key K, call *memoCall[V]) V {
+	defer func() {
+		m.mu.Lock()
+		if call.ok {
+			m.cache.put(key, call.value)
+		}
+		delete(m.calls, key)
+		m.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value = m.f(m.recurse, key)
+	call.ok = true
+	return call.value
+}
+
+func (m *SyncMemo[K, V]) Stats() MemoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cache.stats
+}
+
+func (m *SyncMemo[K, V]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache.reset()
+}
@@ -0,0 +1,141 @@
+package aocutilites
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func fib(recurse func(int) int, n int) int {
+	if n < 2 {
+		return n
+	}
+	return recurse(n-1) + recurse(n-2)
+}
+
+func TestMemoize(t *testing.T) {
+	m := Memoize(fib)
+
+	if got := m.Get(90); got != 2880067194370816120 {
+		t.Fatalf("fib(90) = %d", got)
+	}
+
+	// Each of 0..90 is worked out once, and every n >= 3 finds n-2 already
+	// filled in by n-1
+	want := MemoStats{Hits: 88, Misses: 91, Size: 91}
+	if s := m.Stats(); s != want {
+		t.Fatalf("Stats() = %+v, want %+v", s, want)
+	}
+
+	m.Get(90)
+	if s := m.Stats(); s.Hits != 89 || s.Misses != 91 {
+		t.Fatalf("repeat call: %v", s)
+	}
+
+	m.Reset()
+	if s := m.Stats(); s != (MemoStats{}) {
+		t.Fatalf("Stats() after Reset = %+v", s)
+	}
+}
+
+func TestMemoizeStructKey(t *testing.T) {
+	// Ways to walk from p to the origin moving only up or left
+	type key struct {
+		p Point
+	}
+	m := Memoize(func(recurse func(key) int, k key) int {
+		if k.p.Row == 0 || k.p.Col == 0 {
+			return 1
+		}
+		return recurse(key{k.p.Move(Up)}) + recurse(key{k.p.Move(Left)})
+	})
+
+	if got := m.Get(key{Point{16, 16}}); got != 601080390 {
+		t.Fatalf("paths = %d, want 601080390", got)
+	}
+}
+
+func TestMemoizeBounded(t *testing.T) {
+	calls := 0
+	m := MemoizeBounded(2, func(_ func(int) int, n int) int {
+		calls++
+		return n * n
+	})
+
+	m.Get(1)
+	m.Get(2)
+	m.Get(1) // 1 is now the most recently used
+	m.Get(3) // evicts 2
+	m.Get(1)
+	m.Get(2)
+
+	if calls != 4 {
+		t.Fatalf("f called %d times, want 4", calls)
+	}
+	s := m.Stats()
+	if s.Hits != 2 || s.Misses != 4 || s.Evictions != 2 || s.Size != 2 {
+		t.Fatalf("Stats() = %+v", s)
+	}
+}
+
+func TestMemoizeSync(t *testing.T) {
+	m := MemoizeSync(fib)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if got := m.Get(80); got != 23416728348467685 {
+				t.Errorf("fib(80) = %d", got)
+			}
+		})
+	}
+	wg.Wait()
+
+	// However the goroutines interleave, each n is only worked out once
+	if s := m.Stats(); s.Misses != 81 || s.Size != 81 {
+		t.Fatalf("Stats() = %+v", s)
+	}
+}
+
+func TestMemoizeSyncPanic(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	first := true
+
+	m := MemoizeSync(func(_ func(int) int, n int) int {
+		if first {
+			// Only the first goroutine gets here; the other waits on it
+			first = false
+			close(started)
+			<-release
+			panic("boom")
+		}
+		return n * 2
+	})
+
+	panicked := make(chan any)
+	go func() {
+		defer func() { panicked <- recover() }()
+		m.Get(21)
+	}()
+	<-started
+
+	got := make(chan int)
+	go func() { got <- m.Get(21) }()
+
+	// Wait until the second caller is blocked on the first one's result
+	for m.Stats().Hits == 0 {
+		runtime.Gosched()
+	}
+	close(release)
+
+	if p := <-panicked; p != "boom" {
+		t.Fatalf("first caller recovered %v, want the panic", p)
+	}
+	if v := <-got; v != 42 {
+		t.Fatalf("second caller got %d, want 42", v)
+	}
+	if v := m.Get(21); v != 42 {
+		t.Fatalf("Get after the panic = %d", v)
+	}
+}
@@ -0,0 +1,122 @@
+package aocutilites
+
+import "slices"
+
+// Shortest paths
+
+// Edge is a move to a neighbouring node and what it costs.
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// Graph is anything that can list the moves out of a node. Most puzzles
+// never build the graph and just work the neighbours out, so NeighboursFunc
+// lets a plain function be used.
+type Graph[N comparable] interface {
+	Neighbours(node N) []Edge[N]
+}
+
+type NeighboursFunc[N comparable] func(node N) []Edge[N]
+
+func (f NeighboursFunc[N]) Neighbours(node N) []Edge[N] {
+	return f(node)
+}
+
+// Paths holds the result of a shortest path search.
+type Paths[N comparable] struct {
+	dist map[N]int
+	prev map[N]N
+}
+
+// Dist returns the cost of the cheapest path to node, if it was reached.
+func (p *Paths[N]) Dist(node N) (int, bool) {
+	d, ok := p.dist[node]
+	return d, ok
+}
+
+// PathTo returns the cheapest path from a start node to node, inclusive, or
+// nil if node wasn't reached.
+func (p *Paths[N]) PathTo(node N) []N {
+	if _, ok := p.dist[node]; !ok {
+		return nil
+	}
+
+	path := []N{node}
+	for {
+		prev, ok := p.prev[node]
+		if !ok {
+			break
+		}
+		path = append(path, prev)
+		node = prev
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Dijkstra finds the cheapest path from the nearest of starts to every node
+// it can reach. Edge costs must not be negative.
+func Dijkstra[N comparable](g Graph[N], starts ...N) *Paths[N] {
+	paths, _, _ := search(g, starts, func(N) bool { return false }, func(N) int { return 0 })
+	return paths
+}
+
+// AStar finds the cheapest path from start to the first node that satisfies
+// isGoal. The heuristic must never overestimate the remaining cost and must
+// not drop by more than the cost of any single move, as Manhattan distance on
+// a grid with unit moves doesn't. A heuristic of zero makes it Dijkstra with
+// an early exit.
+func AStar[N comparable](g Graph[N], start N, isGoal func(N) bool, heuristic func(N) int) ([]N, int, bool) {
+	paths, goal, found := search(g, []N{start}, isGoal, heuristic)
+	if !found {
+		return nil, 0, false
+	}
+	return paths.PathTo(goal), paths.dist[goal], true
+}
+
+func search[N comparable](g Graph[N], starts []N, isGoal func(N) bool, heuristic func(N) int) (*Paths[N], N, bool) {
+	paths := &Paths[N]{dist: map[N]int{}, prev: map[N]N{}}
+	queued := map[N]*PQItem[N, int]{}
+	done := map[N]bool{}
+	pq := PriorityQueue[N, int]{}
+
+	for _, start := range starts {
+		paths.dist[start] = 0
+		queued[start] = pq.Push(start, heuristic(start))
+	}
+
+	for {
+		node, _, ok := pq.Pop()
+		if !ok {
+			var zero N
+			return paths, zero, false
+		}
+		delete(queued, node)
+		done[node] = true
+
+		if isGoal(node) {
+			return paths, node, true
+		}
+
+		for _, edge := range g.Neighbours(node) {
+			if done[edge.To] {
+				continue
+			}
+
+			dist := paths.dist[node] + edge.Cost
+			if old, seen := paths.dist[edge.To]; seen && old <= dist {
+				continue
+			}
+			paths.dist[edge.To] = dist
+			paths.prev[edge.To] = node
+
+			priority := dist + heuristic(edge.To)
+			if item, ok := queued[edge.To]; ok {
+				pq.Update(item, priority)
+			} else {
+				queued[edge.To] = pq.Push(edge.To, priority)
+			}
+		}
+	}
+}
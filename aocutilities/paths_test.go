@@ -0,0 +1,109 @@
+package aocutilites
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type cell struct{ row, col int }
+
+// mazeGraph treats '#' as a wall and digits as the cost of stepping onto a
+// cell; anything else costs 1.
+func mazeGraph(maze []string) NeighboursFunc[cell] {
+	return func(c cell) []Edge[cell] {
+		edges := []Edge[cell]{}
+		for _, d := range []cell{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			n := cell{c.row + d.row, c.col + d.col}
+			if n.row < 0 || n.row >= len(maze) || n.col < 0 || n.col >= len(maze[n.row]) {
+				continue
+			}
+			ch := maze[n.row][n.col]
+			switch {
+			case ch == '#':
+				continue
+			case ch >= '0' && ch <= '9':
+				edges = append(edges, Edge[cell]{n, int(ch - '0')})
+			default:
+				edges = append(edges, Edge[cell]{n, 1})
+			}
+		}
+		return edges
+	}
+}
+
+var maze = strings.Fields(`
+S.#.....
+.##.###.
+...9#...
+.#..#.#.
+.#....#E
+`)
+
+func TestDijkstra(t *testing.T) {
+	paths := Dijkstra[cell](mazeGraph(maze), cell{0, 0})
+
+	dist, ok := paths.Dist(cell{4, 7})
+	if !ok || dist != 15 {
+		t.Fatalf("Dist(E) = %d, %v, want 15, true", dist, ok)
+	}
+
+	path := paths.PathTo(cell{4, 7})
+	if len(path) != 16 || path[0] != (cell{0, 0}) || path[15] != (cell{4, 7}) {
+		t.Fatalf("PathTo(E) = %v", path)
+	}
+
+	// The wall cells can never be reached
+	if _, ok := paths.Dist(cell{0, 2}); ok {
+		t.Fatal("reached a wall")
+	}
+	if paths.PathTo(cell{0, 2}) != nil {
+		t.Fatal("PathTo an unreached node should be nil")
+	}
+}
+
+func TestDijkstraMultipleStarts(t *testing.T) {
+	g := mazeGraph(maze)
+	paths := Dijkstra[cell](g, cell{0, 0}, cell{4, 7})
+
+	if dist, _ := paths.Dist(cell{2, 5}); dist != 4 {
+		t.Fatalf("Dist from the nearest start = %d, want 4", dist)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	goal := cell{4, 7}
+	manhattan := func(c cell) int {
+		return max(goal.row-c.row, c.row-goal.row) + max(goal.col-c.col, c.col-goal.col)
+	}
+
+	path, cost, ok := AStar[cell](mazeGraph(maze), cell{0, 0}, func(c cell) bool { return c == goal }, manhattan)
+	if !ok || cost != 15 {
+		t.Fatalf("AStar cost = %d, %v, want 15, true", cost, ok)
+	}
+
+	dijkstraPath := Dijkstra[cell](mazeGraph(maze), cell{0, 0}).PathTo(goal)
+	if len(path) != len(dijkstraPath) {
+		t.Fatalf("AStar path %v is a different length to Dijkstra's %v", path, dijkstraPath)
+	}
+
+	_, _, ok = AStar[cell](mazeGraph(maze), cell{0, 0}, func(c cell) bool { return c == cell{0, 2} }, func(cell) int { return 0 })
+	if ok {
+		t.Fatal("AStar found a path to a wall")
+	}
+}
+
+func TestShortestPathPrefersCheaperLongerRoute(t *testing.T) {
+	g := NeighboursFunc[string](func(n string) []Edge[string] {
+		return map[string][]Edge[string]{
+			"a": {{"b", 10}, {"c", 1}},
+			"c": {{"d", 1}},
+			"d": {{"b", 1}},
+		}[n]
+	})
+
+	paths := Dijkstra[string](g, "a")
+	if got := paths.PathTo("b"); !slices.Equal(got, []string{"a", "c", "d", "b"}) {
+		t.Fatalf("PathTo(b) = %v", got)
+	}
+}
@@ -0,0 +1,313 @@
+package aocutilites
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pattern parsing
+//
+// A pattern is a line with {name} where each value goes, e.g. "{Start}-{End}"
+// or "{X},{Y},{Z}". ParseLines fills a struct from each line, matching each
+// name to the field tagged `aoc:"name"`, or else the field with that name
+// (ignoring case). Fields can be any int, uint or float type, a string, or
+// anything implementing encoding.TextUnmarshaler.
+//
+// A value runs up to the next occurrence of the text after it, but is never
+// empty, so "{Start}-{End}" reads "-3--5" as -3 and -5.
+
+// ParseError says where in a line parsing went wrong. Line and Col are
+// 1-based, and Col counts runes like Tokenize does; Line is 0 when there's
+// only one line.
+type ParseError struct {
+	Line, Col int
+	Text      string
+	Msg       string
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s in %q", e.Line, e.Col, e.Msg, e.Text)
+	}
+	return fmt.Sprintf("column %d: %s in %q", e.Col, e.Msg, e.Text)
+}
+
+type Pattern struct {
+	text string
+	// literals[i] comes before names[i]; the last literal is after the last
+	// name, so there's always one more literal than names
+	literals []string
+	names    []string
+}
+
+func CompilePattern(pattern string) (*Pattern, error) {
+	p := &Pattern{text: pattern}
+
+	rest := pattern
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("pattern %q: unclosed {", pattern)
+		}
+		name := rest[open+1 : open+end]
+		if name == "" {
+			return nil, fmt.Errorf("pattern %q: empty {}", pattern)
+		}
+		if len(p.names) > 0 && open == 0 {
+			return nil, fmt.Errorf("pattern %q: {%s} follows another field with nothing between them", pattern, name)
+		}
+
+		p.literals = append(p.literals, rest[:open])
+		p.names = append(p.names, name)
+		rest = rest[open+end+1:]
+	}
+	p.literals = append(p.literals, rest)
+
+	return p, nil
+}
+
+// MustCompilePattern is CompilePattern for patterns known to be good. It
+// panics on a bad one.
+func MustCompilePattern(pattern string) *Pattern {
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Pattern) String() string {
+	return p.text
+}
+
+// Names returns the field names in the order they appear.
+func (p *Pattern) Names() []string {
+	return p.names
+}
+
+// Match splits line into the values for each name. It also returns the
+// byte offset of each value, for error messages.
+func (p *Pattern) Match(line string) ([]string, []int, error) {
+	values := make([]string, len(p.names))
+	offsets := make([]int, len(p.names))
+
+	fail := func(pos int, format string, args ...any) error {
+		return &ParseError{Col: column(line, pos), Text: line, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	pos := 0
+	if !strings.HasPrefix(line, p.literals[0]) {
+		return nil, nil, fail(0, "expected %q", p.literals[0])
+	}
+	pos += len(p.literals[0])
+
+	for i, name := range p.names {
+		next := p.literals[i+1]
+		last := i == len(p.names)-1
+
+		var end int
+		switch {
+		case last && next == "":
+			end = len(line)
+		case last:
+			// The trailing text has to be at the very end
+			end = len(line) - len(next)
+			if end <= pos || !strings.HasSuffix(line, next) {
+				return nil, nil, fail(pos, "expected {%s} then %q at the end", name, next)
+			}
+		default:
+			if pos >= len(line) {
+				return nil, nil, fail(pos, "expected {%s}", name)
+			}
+			idx := strings.Index(line[pos+1:], next)
+			if idx < 0 {
+				return nil, nil, fail(pos, "expected {%s} then %q", name, next)
+			}
+			end = pos + 1 + idx
+		}
+
+		if end <= pos {
+			return nil, nil, fail(pos, "expected {%s}", name)
+		}
+		values[i], offsets[i] = line[pos:end], pos
+		pos = end + len(next)
+	}
+
+	if len(p.names) == 0 && line != p.literals[0] {
+		return nil, nil, fail(len(p.literals[0]), "unexpected %q", line[len(p.literals[0]):])
+	}
+
+	return values, offsets, nil
+}
+
+// column is the 1-based rune column of byte offset pos in line.
+func column(line string, pos int) int {
+	return utf8.RuneCountInString(line[:pos]) + 1
+}
+
+// binding maps a pattern's names onto the fields of a struct type.
+type binding struct {
+	pattern *Pattern
+	fields  [][]int
+}
+
+func bind(pattern string, t reflect.Type) (*binding, error) {
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pattern %q: %v is not a struct", pattern, t)
+	}
+
+	b := &binding{pattern: p}
+	for _, name := range p.names {
+		field, ok := findField(t, name)
+		if !ok {
+			return nil, fmt.Errorf("pattern %q: %v has no field for {%s}", pattern, t, name)
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("pattern %q: field %s of %v is unexported", pattern, field.Name, t)
+		}
+		b.fields = append(b.fields, field.Index)
+	}
+	return b, nil
+}
+
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	fields := reflect.VisibleFields(t)
+	for _, f := range fields {
+		if f.Tag.Get("aoc") == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+var textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func (b *binding) parse(line string, dst reflect.Value) error {
+	values, offsets, err := b.pattern.Match(line)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		field := dst.FieldByIndex(b.fields[i])
+		if err := setField(field, value); err != nil {
+			return &ParseError{
+				Col:  column(line, offsets[i]),
+				Text: line,
+				Msg:  fmt.Sprintf("{%s}: %v", b.pattern.names[i], err),
+			}
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshaler) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	var err error
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(value, 10, field.Type().Bits()); err == nil {
+			field.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(value, 10, field.Type().Bits()); err == nil {
+			field.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(value, field.Type().Bits()); err == nil {
+			field.SetFloat(f)
+		}
+	default:
+		return fmt.Errorf("can't parse into a %v", field.Type())
+	}
+
+	// strconv's errors repeat the value and function name; keep the reason
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return fmt.Errorf("%q is not a valid %v: %w", value, field.Type(), numErr.Err)
+	}
+	return err
+}
+
+// Parser fills a T from lines that match a pattern. Making one works out
+// the pattern and the fields once, for parsing many lines.
+type Parser[T any] struct {
+	b *binding
+}
+
+func NewParser[T any](pattern string) (*Parser[T], error) {
+	b, err := bind(pattern, reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return &Parser[T]{b: b}, nil
+}
+
+// Parse fills a T from line. An error is a *ParseError with Line 0.
+func (p *Parser[T]) Parse(line string) (T, error) {
+	var v T
+	err := p.b.parse(line, reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+// ParseLine fills a T from line using pattern.
+func ParseLine[T any](line, pattern string) (T, error) {
+	p, err := NewParser[T](pattern)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return p.Parse(line)
+}
+
+// ParseLines fills a T from every line using pattern. Errors give the line
+// number, counting the first line as 1.
+func ParseLines[T any](lines []string, pattern string) ([]T, error) {
+	p, err := NewParser[T](pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]T, len(lines))
+	for i, line := range lines {
+		if out[i], err = p.Parse(line); err != nil {
+			var pe *ParseError
+			if errors.As(err, &pe) {
+				pe.Line = i + 1
+			}
+			return nil, err
+		}
+	}
+	return out, nil
+}
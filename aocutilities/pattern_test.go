@@ -0,0 +1,134 @@
+package aocutilites
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testRange struct {
+	Start int
+	End   int
+}
+
+type testMove struct {
+	Dir   string `aoc:"dir"`
+	Steps uint8  `aoc:"n"`
+	Cost  float64
+}
+
+// testColour implements encoding.TextUnmarshaler
+type testColour [3]byte
+
+func (c *testColour) UnmarshalText(text []byte) error {
+	if len(text) != 7 || text[0] != '#' {
+		return errors.New("not a #rrggbb colour")
+	}
+	for i := range c {
+		hi, lo := strings.IndexByte("0123456789abcdef", text[1+2*i]), strings.IndexByte("0123456789abcdef", text[2+2*i])
+		if hi < 0 || lo < 0 {
+			return errors.New("not a #rrggbb colour")
+		}
+		c[i] = byte(hi<<4 | lo)
+	}
+	return nil
+}
+
+func TestParseLines(t *testing.T) {
+	ranges, err := ParseLines[testRange]([]string{"3-5", "10-14", "-3--1"}, "{start}-{end}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []testRange{{3, 5}, {10, 14}, {-3, -1}}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Fatalf("ranges = %v, want %v", ranges, want)
+		}
+	}
+
+	moves, err := ParseLines[testMove]([]string{"move L 12 for 0.5", "move R 3 for 2"}, "move {dir} {n} for {Cost}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moves[0] != (testMove{"L", 12, 0.5}) || moves[1] != (testMove{"R", 3, 2}) {
+		t.Fatalf("moves = %v", moves)
+	}
+
+	type paint struct{ Colour testColour }
+	p, err := ParseLine[paint]("paint (#ff8000)", "paint ({Colour})")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Colour != (testColour{0xff, 0x80, 0x00}) {
+		t.Fatalf("colour = %v", p.Colour)
+	}
+
+	parser, err := NewParser[testRange]("{Start}-{End}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"3-5", "10-14"} {
+		if _, err := parser.Parse(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var pe *ParseError
+	if _, err := parser.Parse("3+5"); !errors.As(err, &pe) || pe.Line != 0 {
+		t.Fatalf("Parse(3+5) error = %v, want a ParseError without a line", err)
+	}
+}
+
+func TestParseLinesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		pattern string
+		line    int
+		col     int
+		msg     string
+	}{
+		{"missing separator", []string{"1-2", "34"}, "{Start}-{End}", 2, 1, `expected {Start} then "-"`},
+		{"missing value", []string{"1-"}, "{Start}-{End}", 1, 3, "expected {End}"},
+		{"bad number", []string{"1-2", "3-4", "5-x"}, "{Start}-{End}", 3, 3, `{End}: "x" is not a valid int`},
+		{"wrong prefix", []string{"mvoe L 1 for 1"}, "move {dir} {n} for {Cost}", 1, 1, `expected "move "`},
+		{"out of range", []string{"move L 300 for 1"}, "move {dir} {n} for {Cost}", 1, 8, "value out of range"},
+		{"missing suffix", []string{"<1,2"}, "<{Start},{End}>", 1, 4, `expected {End} then ">" at the end`},
+		{"columns count runes", []string{"«1»→x"}, "«{Start}»→{End}", 1, 5, `{End}: "x" is not a valid int`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if strings.HasPrefix(tt.pattern, "move") {
+				_, err = ParseLines[testMove](tt.lines, tt.pattern)
+			} else {
+				_, err = ParseLines[testRange](tt.lines, tt.pattern)
+			}
+
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error = %v, want a ParseError", err)
+			}
+			if pe.Line != tt.line || pe.Col != tt.col || !strings.Contains(pe.Msg, tt.msg) {
+				t.Fatalf("got line %d, column %d, %q; want line %d, column %d, %q",
+					pe.Line, pe.Col, pe.Msg, tt.line, tt.col, tt.msg)
+			}
+		})
+	}
+}
+
+func TestPatternErrors(t *testing.T) {
+	for _, pattern := range []string{"{a", "{}-{b}", "{a}{b}"} {
+		if _, err := CompilePattern(pattern); err == nil {
+			t.Errorf("CompilePattern(%q) didn't fail", pattern)
+		}
+	}
+
+	type private struct{ start int }
+	if _, err := ParseLines[private]([]string{"1"}, "{start}"); err == nil {
+		t.Error("unexported field didn't fail")
+	}
+	if _, err := ParseLines[testRange]([]string{"1"}, "{Middle}"); err == nil {
+		t.Error("unknown field didn't fail")
+	}
+}
@@ -0,0 +1,98 @@
+package aocutilites
+
+import "fmt"
+
+// Points and directions
+//
+// Rows grow downwards and columns to the right, matching the order lines are
+// read in, so Up is row - 1.
+
+type Point struct {
+	Row, Col int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.Row + q.Row, p.Col + q.Col}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.Row - q.Row, p.Col - q.Col}
+}
+
+// Move returns the point one step away in direction d.
+func (p Point) Move(d Direction) Point {
+	return p.Add(d.Delta())
+}
+
+// Manhattan returns the taxicab distance between p and q.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.Row-q.Row) + abs(p.Col-q.Col)
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.Row, p.Col)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Direction is one of the eight compass directions, in clockwise order.
+type Direction int
+
+const (
+	Up Direction = iota
+	UpRight
+	Right
+	DownRight
+	Down
+	DownLeft
+	Left
+	UpLeft
+)
+
+// Directions4 are the orthogonal directions and Directions8 adds the
+// diagonals, both clockwise from Up.
+var (
+	Directions4 = []Direction{Up, Right, Down, Left}
+	Directions8 = []Direction{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+)
+
+var directionDeltas = [...]Point{
+	Up:        {-1, 0},
+	UpRight:   {-1, 1},
+	Right:     {0, 1},
+	DownRight: {1, 1},
+	Down:      {1, 0},
+	DownLeft:  {1, -1},
+	Left:      {0, -1},
+	UpLeft:    {-1, -1},
+}
+
+var directionNames = [...]string{"Up", "UpRight", "Right", "DownRight", "Down", "DownLeft", "Left", "UpLeft"}
+
+// Delta is the change in position from one step in this direction.
+func (d Direction) Delta() Point {
+	return directionDeltas[d]
+}
+
+// TurnRight turns 90 degrees clockwise.
+func (d Direction) TurnRight() Direction {
+	return (d + 2) % 8
+}
+
+// TurnLeft turns 90 degrees anticlockwise.
+func (d Direction) TurnLeft() Direction {
+	return (d + 6) % 8
+}
+
+func (d Direction) Opposite() Direction {
+	return (d + 4) % 8
+}
+
+func (d Direction) String() string {
+	return directionNames[d]
+}
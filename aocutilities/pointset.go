@@ -0,0 +1,157 @@
+package aocutilites
+
+import "strings"
+
+// PointSet
+//
+// A sparse grid that only stores the points that are occupied. Useful when
+// most of the grid is empty, or when it grows or moves about.
+
+type PointSet map[Point]struct{}
+
+func NewPointSet(points ...Point) PointSet {
+	s := make(PointSet, len(points))
+	for _, p := range points {
+		s[p] = struct{}{}
+	}
+	return s
+}
+
+// ParsePointSet returns the position of every glyph in lines.
+func ParsePointSet(lines []string, glyph rune) PointSet {
+	s := PointSet{}
+	for row, line := range lines {
+		col := 0
+		for _, r := range line {
+			if r == glyph {
+				s[Point{row, col}] = struct{}{}
+			}
+			col++
+		}
+	}
+	return s
+}
+
+func (s PointSet) Add(p Point) {
+	s[p] = struct{}{}
+}
+
+func (s PointSet) Remove(p Point) {
+	delete(s, p)
+}
+
+func (s PointSet) Contains(p Point) bool {
+	_, ok := s[p]
+	return ok
+}
+
+func (s PointSet) Len() int {
+	return len(s)
+}
+
+func (s PointSet) Clone() PointSet {
+	c := make(PointSet, len(s))
+	for p := range s {
+		c[p] = struct{}{}
+	}
+	return c
+}
+
+// CountNeighbours returns how many of the points next to p, in the given
+// directions, are in the set. Use Directions4 or Directions8.
+func (s PointSet) CountNeighbours(p Point, directions []Direction) int {
+	count := 0
+	for _, d := range directions {
+		if _, ok := s[p.Move(d)]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// Bounds returns the top-left and bottom-right corners of the smallest box
+// holding every point. It returns false for an empty set.
+func (s PointSet) Bounds() (lo, hi Point, ok bool) {
+	for p := range s {
+		if !ok {
+			lo, hi, ok = p, p, true
+			continue
+		}
+		lo.Row = min(lo.Row, p.Row)
+		lo.Col = min(lo.Col, p.Col)
+		hi.Row = max(hi.Row, p.Row)
+		hi.Col = max(hi.Col, p.Col)
+	}
+	return lo, hi, ok
+}
+
+// Translate returns the set moved by offset.
+func (s PointSet) Translate(offset Point) PointSet {
+	t := make(PointSet, len(s))
+	for p := range s {
+		t[p.Add(offset)] = struct{}{}
+	}
+	return t
+}
+
+// Rotate returns the set turned clockwise about the origin by the given
+// number of quarter turns. Negative turns go anticlockwise.
+func (s PointSet) Rotate(turns int) PointSet {
+	turns = ((turns % 4) + 4) % 4
+
+	r := make(PointSet, len(s))
+	for p := range s {
+		for range turns {
+			p = Point{p.Col, -p.Row}
+		}
+		r[p] = struct{}{}
+	}
+	return r
+}
+
+// Union returns the points in either set.
+func (s PointSet) Union(other PointSet) PointSet {
+	u := s.Clone()
+	for p := range other {
+		u[p] = struct{}{}
+	}
+	return u
+}
+
+// Difference returns the points in s that aren't in other.
+func (s PointSet) Difference(other PointSet) PointSet {
+	d := PointSet{}
+	for p := range s {
+		if _, ok := other[p]; !ok {
+			d[p] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Render draws the set's bounding box as text, with glyph for each point and
+// '.' everywhere else.
+func (s PointSet) Render(glyph rune) string {
+	lo, hi, ok := s.Bounds()
+	if !ok {
+		return ""
+	}
+	return s.RenderBox(lo, hi, glyph)
+}
+
+// RenderBox is Render over a fixed box, so a set that shrinks or moves can be
+// drawn in the same frame each time.
+func (s PointSet) RenderBox(lo, hi Point, glyph rune) string {
+	var sb strings.Builder
+	for row := lo.Row; row <= hi.Row; row++ {
+		for col := lo.Col; col <= hi.Col; col++ {
+			if _, ok := s[Point{row, col}]; ok {
+				sb.WriteRune(glyph)
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package aocutilites
+
+import "testing"
+
+func TestPointSet(t *testing.T) {
+	s := ParsePointSet([]string{"..#", ".##", "..."}, '#')
+
+	if s.Len() != 3 || !s.Contains(Point{1, 1}) || s.Contains(Point{0, 0}) {
+		t.Fatalf("ParsePointSet gave %v", s)
+	}
+	if n := s.CountNeighbours(Point{0, 1}, Directions8); n != 3 {
+		t.Fatalf("CountNeighbours8 = %d, want 3", n)
+	}
+	if n := s.CountNeighbours(Point{0, 1}, Directions4); n != 2 {
+		t.Fatalf("CountNeighbours4 = %d, want 2", n)
+	}
+
+	lo, hi, ok := s.Bounds()
+	if !ok || lo != (Point{0, 1}) || hi != (Point{1, 2}) {
+		t.Fatalf("Bounds() = %v, %v, %v", lo, hi, ok)
+	}
+	if _, _, ok := (PointSet{}).Bounds(); ok {
+		t.Fatal("Bounds of an empty set returned ok")
+	}
+
+	if got := s.Render('@'); got != ".@\n@@\n" {
+		t.Fatalf("Render() = %q", got)
+	}
+	if got := s.RenderBox(Point{0, 0}, Point{1, 3}, '#'); got != "..#.\n.##.\n" {
+		t.Fatalf("RenderBox() = %q", got)
+	}
+}
+
+func TestPointSetTransforms(t *testing.T) {
+	s := NewPointSet(Point{0, 0}, Point{0, 1}, Point{1, 0})
+
+	moved := s.Translate(Point{2, 3})
+	if !moved.Contains(Point{2, 4}) || moved.Contains(Point{0, 0}) {
+		t.Fatalf("Translate gave %v", moved)
+	}
+
+	// Turning right takes Right to Down
+	r := s.Rotate(1)
+	if !r.Contains(Point{1, 0}) || !r.Contains(Point{0, -1}) || r.Len() != 3 {
+		t.Fatalf("Rotate(1) gave %v", r)
+	}
+	if back := r.Rotate(-1); back.Difference(s).Len() != 0 || back.Len() != 3 {
+		t.Fatalf("Rotate(-1) didn't undo Rotate(1): %v", back)
+	}
+	if full := s.Rotate(4); full.Difference(s).Len() != 0 {
+		t.Fatalf("Rotate(4) changed the set: %v", full)
+	}
+
+	other := NewPointSet(Point{0, 1}, Point{5, 5})
+	if u := s.Union(other); u.Len() != 4 {
+		t.Fatalf("Union has %d points, want 4", u.Len())
+	}
+	if d := s.Difference(other); d.Len() != 2 || d.Contains(Point{0, 1}) {
+		t.Fatalf("Difference gave %v", d)
+	}
+	if s.Len() != 3 {
+		t.Fatal("set operations changed the original")
+	}
+}
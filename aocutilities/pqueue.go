@@ -0,0 +1,146 @@
+package aocutilites
+
+import (
+	"cmp"
+	"container/heap"
+)
+
+// Priority queue
+//
+// PriorityQueue wraps container/heap so callers don't have to implement
+// heap.Interface or type-assert what comes out of it. The zero value is a
+// min-queue; use NewMaxPriorityQueue for a max-queue.
+
+// PQItem is a handle to a queued item, used to change its priority or remove
+// it before it is popped.
+type PQItem[T any, P cmp.Ordered] struct {
+	Value    T
+	priority P
+	index    int // position in the heap, -1 once popped or removed
+}
+
+func (it *PQItem[T, P]) Priority() P {
+	return it.priority
+}
+
+type pqHeap[T any, P cmp.Ordered] struct {
+	items []*PQItem[T, P]
+	max   bool
+}
+
+func (h *pqHeap[T, P]) Len() int { return len(h.items) }
+
+func (h *pqHeap[T, P]) Less(i, j int) bool {
+	if h.max {
+		return h.items[i].priority > h.items[j].priority
+	}
+	return h.items[i].priority < h.items[j].priority
+}
+
+func (h *pqHeap[T, P]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *pqHeap[T, P]) Push(x any) {
+	item := x.(*PQItem[T, P])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *pqHeap[T, P]) Pop() any {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items[last] = nil
+	h.items = h.items[:last]
+	item.index = -1
+	return item
+}
+
+type PriorityQueue[T any, P cmp.Ordered] struct {
+	h pqHeap[T, P]
+}
+
+func NewMinPriorityQueue[T any, P cmp.Ordered]() *PriorityQueue[T, P] {
+	return &PriorityQueue[T, P]{}
+}
+
+func NewMaxPriorityQueue[T any, P cmp.Ordered]() *PriorityQueue[T, P] {
+	return &PriorityQueue[T, P]{h: pqHeap[T, P]{max: true}}
+}
+
+// Push adds an item and returns its handle.
+func (pq *PriorityQueue[T, P]) Push(value T, priority P) *PQItem[T, P] {
+	item := &PQItem[T, P]{Value: value, priority: priority}
+	heap.Push(&pq.h, item)
+	return item
+}
+
+// Init replaces the contents of the queue with items in O(n), which is
+// cheaper than pushing them one at a time when only a few will be popped.
+func (pq *PriorityQueue[T, P]) Init(values []T, priority func(T) P) {
+	// One allocation for all the items rather than one each
+	items := make([]PQItem[T, P], len(values))
+	pq.h.items = make([]*PQItem[T, P], len(values))
+	for i, v := range values {
+		items[i] = PQItem[T, P]{Value: v, priority: priority(v), index: i}
+		pq.h.items[i] = &items[i]
+	}
+	heap.Init(&pq.h)
+}
+
+// Pop removes and returns the item with the lowest priority, or the highest
+// for a max-queue.
+func (pq *PriorityQueue[T, P]) Pop() (T, P, bool) {
+	if len(pq.h.items) == 0 {
+		var zero T
+		var zeroP P
+		return zero, zeroP, false
+	}
+	item := heap.Pop(&pq.h).(*PQItem[T, P])
+	return item.Value, item.priority, true
+}
+
+// Peek returns the item Pop would return without removing it.
+func (pq *PriorityQueue[T, P]) Peek() (T, P, bool) {
+	if len(pq.h.items) == 0 {
+		var zero T
+		var zeroP P
+		return zero, zeroP, false
+	}
+	item := pq.h.items[0]
+	return item.Value, item.priority, true
+}
+
+// Update changes the priority of a queued item, e.g. decrease-key in
+// Dijkstra. It returns false if the item has already left the queue.
+func (pq *PriorityQueue[T, P]) Update(item *PQItem[T, P], priority P) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	item.priority = priority
+	heap.Fix(&pq.h, item.index)
+	return true
+}
+
+// Remove takes an item out of the queue before it is popped.
+func (pq *PriorityQueue[T, P]) Remove(item *PQItem[T, P]) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	heap.Remove(&pq.h, item.index)
+	return true
+}
+
+func (pq *PriorityQueue[T, P]) contains(item *PQItem[T, P]) bool {
+	return item.index >= 0 && item.index < len(pq.h.items) && pq.h.items[item.index] == item
+}
+
+func (pq *PriorityQueue[T, P]) IsEmpty() bool {
+	return len(pq.h.items) == 0
+}
+
+func (pq *PriorityQueue[T, P]) Len() int {
+	return len(pq.h.items)
+}
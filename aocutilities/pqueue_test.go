@@ -0,0 +1,84 @@
+package aocutilites
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueueOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := r.Perm(100)
+
+	var minQ PriorityQueue[int, int]
+	maxQ := NewMaxPriorityQueue[int, int]()
+	for _, v := range values {
+		minQ.Push(v, v)
+		maxQ.Push(v, v)
+	}
+
+	for want := 0; want < 100; want++ {
+		if got, _, _ := minQ.Peek(); got != want {
+			t.Fatalf("min Peek() = %d, want %d", got, want)
+		}
+		if got, _, _ := minQ.Pop(); got != want {
+			t.Fatalf("min Pop() = %d, want %d", got, want)
+		}
+		if got, _, _ := maxQ.Pop(); got != 99-want {
+			t.Fatalf("max Pop() = %d, want %d", got, 99-want)
+		}
+	}
+
+	if _, _, ok := minQ.Pop(); ok {
+		t.Fatal("Pop on an empty queue returned ok")
+	}
+}
+
+func TestPriorityQueueUpdateAndRemove(t *testing.T) {
+	pq := NewMinPriorityQueue[string, int]()
+	a := pq.Push("a", 10)
+	b := pq.Push("b", 20)
+	c := pq.Push("c", 30)
+
+	if !pq.Update(c, 5) {
+		t.Fatal("Update of a queued item returned false")
+	}
+	if !pq.Remove(a) {
+		t.Fatal("Remove of a queued item returned false")
+	}
+	if c.Priority() != 5 {
+		t.Fatalf("Priority() = %d, want 5", c.Priority())
+	}
+
+	got := []string{}
+	for !pq.IsEmpty() {
+		v, _, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"c", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("popped %v, want %v", got, want)
+	}
+
+	if pq.Update(b, 1) || pq.Remove(a) {
+		t.Fatal("items that have left the queue can't be updated or removed")
+	}
+}
+
+func TestPriorityQueueInit(t *testing.T) {
+	pq := NewMaxPriorityQueue[string, int]()
+	pq.Push("discarded", 100)
+	pq.Init([]string{"bb", "a", "dddd", "ccc"}, func(s string) int { return len(s) })
+
+	if pq.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", pq.Len())
+	}
+
+	got := []string{}
+	for !pq.IsEmpty() {
+		v, _, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"dddd", "ccc", "bb", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("popped %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,192 @@
+package aocutilites
+
+import "iter"
+
+// Queue and Deque
+//
+// Both are backed by a ring buffer that doubles when it is full, so pushing
+// and popping at either end is amortised O(1) and nothing is shifted. Like
+// Stack, the zero value is ready to use.
+
+type ring[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+func (r *ring[T]) grow() {
+	size := 2 * len(r.buf)
+	if size == 0 {
+		size = 8
+	}
+
+	buf := make([]T, size)
+	// Unwrap so the oldest item is at the start of the new buffer
+	copied := copy(buf, r.buf[r.head:])
+	copy(buf[copied:], r.buf[:r.head])
+
+	r.buf = buf
+	r.head = 0
+}
+
+// index maps a position from the front to an index into buf.
+func (r *ring[T]) index(i int) int {
+	return (r.head + i) % len(r.buf)
+}
+
+func (r *ring[T]) pushBack(item T) {
+	if r.n == len(r.buf) {
+		r.grow()
+	}
+	r.buf[r.index(r.n)] = item
+	r.n++
+}
+
+func (r *ring[T]) pushFront(item T) {
+	if r.n == len(r.buf) {
+		r.grow()
+	}
+	r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+	r.buf[r.head] = item
+	r.n++
+}
+
+func (r *ring[T]) popFront() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	item := r.buf[r.head]
+	r.buf[r.head] = zero // don't hold on to popped items
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+	return item, true
+}
+
+func (r *ring[T]) popBack() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	i := r.index(r.n - 1)
+	item := r.buf[i]
+	r.buf[i] = zero
+	r.n--
+	return item, true
+}
+
+func (r *ring[T]) at(i int) (T, bool) {
+	if i < 0 || i >= r.n {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.index(i)], true
+}
+
+func (r *ring[T]) clear() {
+	clear(r.buf)
+	r.head = 0
+	r.n = 0
+}
+
+func (r *ring[T]) all() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.n; i++ {
+			if !yield(r.buf[r.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Queue is a first in, first out queue.
+type Queue[T any] struct {
+	items ring[T]
+}
+
+// Push adds an item to the back of the queue.
+func (q *Queue[T]) Push(item T) {
+	q.items.pushBack(item)
+}
+
+// Pop removes and returns the item at the front of the queue.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.items.popFront()
+}
+
+// Peek returns the item at the front of the queue without removing it.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.items.at(0)
+}
+
+func (q *Queue[T]) IsEmpty() bool {
+	return q.items.n == 0
+}
+
+func (q *Queue[T]) Len() int {
+	return q.items.n
+}
+
+// Clear empties the queue but keeps its capacity.
+func (q *Queue[T]) Clear() {
+	q.items.clear()
+}
+
+// All iterates from the front of the queue to the back without removing
+// anything.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.items.all()
+}
+
+// Deque is a double-ended queue.
+type Deque[T any] struct {
+	items ring[T]
+}
+
+func (d *Deque[T]) PushFront(item T) {
+	d.items.pushFront(item)
+}
+
+func (d *Deque[T]) PushBack(item T) {
+	d.items.pushBack(item)
+}
+
+func (d *Deque[T]) PopFront() (T, bool) {
+	return d.items.popFront()
+}
+
+func (d *Deque[T]) PopBack() (T, bool) {
+	return d.items.popBack()
+}
+
+func (d *Deque[T]) PeekFront() (T, bool) {
+	return d.items.at(0)
+}
+
+func (d *Deque[T]) PeekBack() (T, bool) {
+	return d.items.at(d.items.n - 1)
+}
+
+// At returns the item i places from the front.
+func (d *Deque[T]) At(i int) (T, bool) {
+	return d.items.at(i)
+}
+
+func (d *Deque[T]) IsEmpty() bool {
+	return d.items.n == 0
+}
+
+func (d *Deque[T]) Len() int {
+	return d.items.n
+}
+
+// Clear empties the deque but keeps its capacity.
+func (d *Deque[T]) Clear() {
+	d.items.clear()
+}
+
+// All iterates from the front of the deque to the back without removing
+// anything.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return d.items.all()
+}
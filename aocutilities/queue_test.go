@@ -0,0 +1,174 @@
+package aocutilites
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+
+	if _, ok := q.Pop(); ok {
+		t.Fatal("Pop on an empty queue returned ok")
+	}
+	if _, ok := q.Peek(); ok {
+		t.Fatal("Peek on an empty queue returned ok")
+	}
+
+	// Interleave pushes and pops so the ring wraps around before it grows
+	next := 0
+	for round := 0; round < 50; round++ {
+		for i := 0; i < 3; i++ {
+			q.Push(round*3 + i)
+		}
+		for i := 0; i < 2; i++ {
+			got, ok := q.Pop()
+			if !ok || got != next {
+				t.Fatalf("Pop() = %d, %v, want %d, true", got, ok, next)
+			}
+			next++
+		}
+	}
+
+	if q.Len() != 50 {
+		t.Fatalf("Len() = %d, want 50", q.Len())
+	}
+	if got, _ := q.Peek(); got != next {
+		t.Fatalf("Peek() = %d, want %d", got, next)
+	}
+
+	want := []int{}
+	for i := next; i < 150; i++ {
+		want = append(want, i)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+
+	q.Clear()
+	if !q.IsEmpty() || q.Len() != 0 {
+		t.Fatal("queue not empty after Clear")
+	}
+	q.Push(7)
+	if got, ok := q.Pop(); !ok || got != 7 {
+		t.Fatalf("Pop() after Clear = %d, %v, want 7, true", got, ok)
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[string]
+
+	if _, ok := d.PopFront(); ok {
+		t.Fatal("PopFront on an empty deque returned ok")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Fatal("PopBack on an empty deque returned ok")
+	}
+	if _, ok := d.PeekBack(); ok {
+		t.Fatal("PeekBack on an empty deque returned ok")
+	}
+
+	// Pushing on the front of an empty deque wraps round the ring straight away
+	for _, s := range []string{"c", "b", "a"} {
+		d.PushFront(s)
+	}
+	for _, s := range []string{"d", "e", "f", "g", "h", "i", "j"} {
+		d.PushBack(s)
+	}
+
+	want := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	if got := slices.Collect(d.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+	if got, _ := d.At(3); got != "d" {
+		t.Fatalf("At(3) = %q, want \"d\"", got)
+	}
+	if _, ok := d.At(10); ok {
+		t.Fatal("At past the end returned ok")
+	}
+
+	if got, _ := d.PeekFront(); got != "a" {
+		t.Fatalf("PeekFront() = %q, want \"a\"", got)
+	}
+	if got, _ := d.PeekBack(); got != "j" {
+		t.Fatalf("PeekBack() = %q, want \"j\"", got)
+	}
+	if got, _ := d.PopBack(); got != "j" {
+		t.Fatalf("PopBack() = %q, want \"j\"", got)
+	}
+	if got, _ := d.PopFront(); got != "a" {
+		t.Fatalf("PopFront() = %q, want \"a\"", got)
+	}
+	if d.Len() != 8 {
+		t.Fatalf("Len() = %d, want 8", d.Len())
+	}
+
+	d.Clear()
+	if !d.IsEmpty() {
+		t.Fatal("deque not empty after Clear")
+	}
+}
+
+func TestQueueReleasesPoppedItems(t *testing.T) {
+	var q Queue[*int]
+	for i := 0; i < 4; i++ {
+		q.Push(new(int))
+	}
+	q.Pop()
+
+	for _, item := range q.items.buf[:1] {
+		if item != nil {
+			t.Fatal("popped item still referenced by the buffer")
+		}
+	}
+}
+
+// The time per operation should stay flat as n grows.
+
+func benchmarkQueue(b *testing.B, n int) {
+	for b.Loop() {
+		var q Queue[int]
+		for i := 0; i < n; i++ {
+			q.Push(i)
+		}
+		for !q.IsEmpty() {
+			q.Pop()
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/item")
+}
+
+func BenchmarkQueue1K(b *testing.B)   { benchmarkQueue(b, 1_000) }
+func BenchmarkQueue100K(b *testing.B) { benchmarkQueue(b, 100_000) }
+
+func benchmarkDeque(b *testing.B, n int) {
+	for b.Loop() {
+		var d Deque[int]
+		for i := 0; i < n; i++ {
+			if i%2 == 0 {
+				d.PushFront(i)
+			} else {
+				d.PushBack(i)
+			}
+		}
+		for !d.IsEmpty() {
+			d.PopFront()
+			d.PopBack()
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/item")
+}
+
+func BenchmarkDeque1K(b *testing.B)   { benchmarkDeque(b, 1_000) }
+func BenchmarkDeque100K(b *testing.B) { benchmarkDeque(b, 100_000) }
+
+// A steady-state queue that never grows past a few items shouldn't allocate.
+func BenchmarkQueueSteadyState(b *testing.B) {
+	var q Queue[int]
+	q.Push(0)
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		q.Push(i)
+		q.Pop()
+	}
+}
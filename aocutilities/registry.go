@@ -0,0 +1,138 @@
+package aocutilites
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Solver is implemented by every day. Parse reads the puzzle input once and
+// the parts work from the parsed value, so they must not modify it.
+type Solver[T any] interface {
+	Parse(r io.Reader) (T, error)
+	Part1(input T) (int, error)
+	Part2(input T) (int, error)
+}
+
+// Visualiser is optionally implemented by a Solver that can show its working.
+type Visualiser[T any] interface {
+	Visualise(input T, part int) error
+}
+
+// Puzzle registry
+
+// Puzzle is a registered Solver with its input type erased, so the runner
+// and other tooling can treat every day the same way. Visualise is nil when
+// the day has no visualisation. Inputs holds named inputs that the day
+// carries with it, such as its embedded example.
+type Puzzle struct {
+	Day       int
+	Parse     func(r io.Reader) (any, error)
+	Part1     func(input any) (int, error)
+	Part2     func(input any) (int, error)
+	Visualise func(input any, part int) error
+	Inputs    map[string]string
+
+	// solvers holds the settings for named inputs that don't use the day's
+	// own, e.g. an example with smaller limits than the real puzzle.
+	solvers map[string]Puzzle
+}
+
+// ForInput returns the puzzle set up to solve the named input. That's p
+// itself unless the input was registered with its own solver.
+func (p Puzzle) ForInput(name string) Puzzle {
+	if s, ok := p.solvers[name]; ok {
+		return s
+	}
+	return p
+}
+
+// Part returns the function that solves the given part (1 or 2).
+func (p Puzzle) Part(part int) (func(input any) (int, error), bool) {
+	switch part {
+	case 1:
+		return p.Part1, true
+	case 2:
+		return p.Part2, true
+	}
+	return nil, false
+}
+
+var puzzles = map[int]Puzzle{}
+
+// Register makes a day available to the runner. Days call it from init.
+func Register[T any](day int, s Solver[T]) {
+	if _, ok := puzzles[day]; ok {
+		panic(fmt.Sprintf("day %d registered twice", day))
+	}
+
+	p := newPuzzle(day, s)
+	p.Inputs = map[string]string{}
+	p.solvers = map[string]Puzzle{}
+	puzzles[day] = p
+}
+
+func newPuzzle[T any](day int, s Solver[T]) Puzzle {
+	p := Puzzle{
+		Day: day,
+		Parse: func(r io.Reader) (any, error) {
+			return s.Parse(r)
+		},
+		Part1: func(input any) (int, error) {
+			return s.Part1(input.(T))
+		},
+		Part2: func(input any) (int, error) {
+			return s.Part2(input.(T))
+		},
+	}
+
+	if v, ok := s.(Visualiser[T]); ok {
+		p.Visualise = func(input any, part int) error {
+			return v.Visualise(input.(T), part)
+		}
+	}
+	return p
+}
+
+// RegisterInput adds a named input to a registered day, e.g.
+//
+//	//go:embed testdata/example.txt
+//	var example string
+//
+//	aocutilites.RegisterInput(1, "example", example)
+func RegisterInput(day int, name, data string) {
+	p, ok := puzzles[day]
+	if !ok {
+		panic(fmt.Sprintf("day %d must be registered before its inputs", day))
+	}
+	p.Inputs[name] = data
+}
+
+// RegisterInputSolver is RegisterInput for an input that needs different
+// solver settings from the day's own, such as an example that runs fewer
+// steps than the real puzzle:
+//
+//	aocutilites.Register(8, solver{numConnections: 1000})
+//	aocutilites.RegisterInputSolver(8, "example", example, solver{numConnections: 10})
+func RegisterInputSolver[T any](day int, name, data string, s Solver[T]) {
+	RegisterInput(day, name, data)
+
+	variant := newPuzzle(day, s)
+	variant.Inputs = puzzles[day].Inputs
+	puzzles[day].solvers[name] = variant
+}
+
+func Lookup(day int) (Puzzle, bool) {
+	p, ok := puzzles[day]
+	return p, ok
+}
+
+// Days returns the registered days in order.
+func Days() []int {
+	days := []int{}
+	for day := range puzzles {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
@@ -0,0 +1,238 @@
+// Package seq has combinators for Go's range-over-func iterators.
+//
+// Functions that take a slice index into it rather than consuming an
+// iterator, since they need to see elements more than once. Those that
+// yield a []T reuse it between iterations, so copy it to keep it.
+package seq
+
+import "iter"
+
+// Map yields f of every value in s.
+func Map[T, U any](s iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter yields the values in s that keep returns true for.
+func Filter[T any](s iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce folds s into a single value, starting from initial.
+func Reduce[T, A any](s iter.Seq[T], initial A, f func(A, T) A) A {
+	acc := initial
+	for v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Enumerate pairs each value in s with its position.
+func Enumerate[T any](s iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range s {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Zip pairs up values from a and b, stopping when either runs out.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// PairIndices yields every i, j with 0 <= i < j < n.
+func PairIndices(n int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if !yield(i, j) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Pairs yields every pair of items, each pair once, in the order they appear.
+func Pairs[T any](items []T) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		for i, j := range PairIndices(len(items)) {
+			if !yield(items[i], items[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Windows yields every run of size consecutive items, e.g. [1 2] [2 3] [3 4]
+// for a size of 2. The windows share the items' storage.
+func Windows[T any](items []T, size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("seq: window size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		for i := 0; i+size <= len(items); i++ {
+			if !yield(items[i : i+size : i+size]) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks splits items into consecutive pieces of size items, the last one
+// possibly shorter. The chunks share the items' storage.
+func Chunks[T any](items []T, size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("seq: chunk size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		for i := 0; i < len(items); i += size {
+			end := min(i+size, len(items))
+			if !yield(items[i:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// Combinations yields every way of choosing k of the items, keeping their
+// order, e.g. [a b] [a c] [b c] for k = 2. The slice is reused.
+func Combinations[T any](items []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		if k < 0 || k > n {
+			return
+		}
+
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		out := make([]T, k)
+
+		for {
+			for i, j := range idx {
+				out[i] = items[j]
+			}
+			if !yield(out) {
+				return
+			}
+
+			// Find the rightmost index that can still move up
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+// Permutations yields every ordering of the items, in lexicographic order
+// of their positions. The slice is reused.
+func Permutations[T any](items []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		out := make([]T, n)
+
+		for {
+			for i, j := range idx {
+				out[i] = items[j]
+			}
+			if !yield(out) {
+				return
+			}
+
+			// Next permutation: find the last ascent, swap it with the
+			// smallest larger value after it and reverse the tail
+			i := n - 2
+			for i >= 0 && idx[i] > idx[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := n - 1
+			for idx[j] < idx[i] {
+				j--
+			}
+			idx[i], idx[j] = idx[j], idx[i]
+			for l, r := i+1, n-1; l < r; l, r = l+1, r-1 {
+				idx[l], idx[r] = idx[r], idx[l]
+			}
+		}
+	}
+}
+
+// Product yields the cartesian product of lists: one item from each, with
+// the last list changing fastest. The slice is reused.
+func Product[T any](lists ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for _, l := range lists {
+			if len(l) == 0 {
+				return
+			}
+		}
+
+		idx := make([]int, len(lists))
+		out := make([]T, len(lists))
+		for {
+			for i, j := range idx {
+				out[i] = lists[i][j]
+			}
+			if !yield(out) {
+				return
+			}
+
+			// Count up like an odometer
+			i := len(lists) - 1
+			for i >= 0 {
+				idx[i]++
+				if idx[i] < len(lists[i]) {
+					break
+				}
+				idx[i] = 0
+				i--
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package seq
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// collect copies each yielded slice, since they're reused.
+func collect[T any](s func(func([]T) bool)) [][]T {
+	out := [][]T{}
+	for v := range s {
+		out = append(out, slices.Clone(v))
+	}
+	return out
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	nums := slices.Values([]int{1, 2, 3, 4, 5, 6})
+
+	evens := Filter(nums, func(n int) bool { return n%2 == 0 })
+	squares := Map(evens, func(n int) int { return n * n })
+	if got := slices.Collect(squares); !slices.Equal(got, []int{4, 16, 36}) {
+		t.Fatalf("squares of evens = %v", got)
+	}
+
+	sum := Reduce(nums, 0, func(acc, n int) int { return acc + n })
+	if sum != 21 {
+		t.Fatalf("Reduce sum = %d, want 21", sum)
+	}
+	joined := Reduce(Map(nums, strconv.Itoa), "", func(acc, s string) string { return acc + s })
+	if joined != "123456" {
+		t.Fatalf("Reduce join = %q", joined)
+	}
+}
+
+func TestEnumerateZip(t *testing.T) {
+	letters := slices.Values([]string{"a", "b", "c"})
+
+	got := []string{}
+	for i, s := range Enumerate(letters) {
+		got = append(got, fmt.Sprint(i, s))
+	}
+	if !slices.Equal(got, []string{"0a", "1b", "2c"}) {
+		t.Fatalf("Enumerate = %v", got)
+	}
+
+	got = got[:0]
+	for n, s := range Zip(slices.Values([]int{1, 2, 3, 4}), letters) {
+		got = append(got, fmt.Sprint(n, s))
+	}
+	if !slices.Equal(got, []string{"1a", "2b", "3c"}) {
+		t.Fatalf("Zip = %v", got)
+	}
+}
+
+func TestPairs(t *testing.T) {
+	got := []string{}
+	for a, b := range Pairs([]string{"a", "b", "c"}) {
+		got = append(got, a+b)
+	}
+	if !slices.Equal(got, []string{"ab", "ac", "bc"}) {
+		t.Fatalf("Pairs = %v", got)
+	}
+
+	n := 0
+	for range PairIndices(100) {
+		n++
+	}
+	if n != 100*99/2 {
+		t.Fatalf("PairIndices(100) gave %d pairs", n)
+	}
+
+	// Stopping early mustn't panic
+	for range Pairs([]int{1, 2, 3}) {
+		break
+	}
+}
+
+func TestWindowsChunks(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name string
+		got  [][]int
+		want [][]int
+	}{
+		{"windows of 2", collect(Windows(items, 2)), [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}},
+		{"window too big", collect(Windows(items, 6)), [][]int{}},
+		{"chunks of 2", collect(Chunks(items, 2)), [][]int{{1, 2}, {3, 4}, {5}}},
+		{"one chunk", collect(Chunks(items, 10)), [][]int{{1, 2, 3, 4, 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.EqualFunc(tt.got, tt.want, slices.Equal) {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombinatorics(t *testing.T) {
+	abc := []string{"a", "b", "c"}
+
+	tests := []struct {
+		name string
+		got  [][]string
+		want [][]string
+	}{
+		{"combinations of 2", collect(Combinations(abc, 2)), [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}}},
+		{"combinations of 0", collect(Combinations(abc, 0)), [][]string{{}}},
+		{"combinations of 4", collect(Combinations(abc, 4)), [][]string{}},
+		{"permutations", collect(Permutations(abc)), [][]string{
+			{"a", "b", "c"}, {"a", "c", "b"}, {"b", "a", "c"},
+			{"b", "c", "a"}, {"c", "a", "b"}, {"c", "b", "a"},
+		}},
+		{"product", collect(Product([]string{"a", "b"}, []string{"x", "y"})), [][]string{
+			{"a", "x"}, {"a", "y"}, {"b", "x"}, {"b", "y"},
+		}},
+		{"product with an empty list", collect(Product(abc, []string{})), [][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.EqualFunc(tt.got, tt.want, slices.Equal) {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// Positions, not values, are permuted, so duplicates repeat
+	n := 0
+	for range Permutations([]int{1, 1, 2, 3}) {
+		n++
+	}
+	if n != 24 {
+		t.Fatalf("Permutations of 4 items gave %d", n)
+	}
+}
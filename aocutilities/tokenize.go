@@ -0,0 +1,67 @@
+package aocutilites
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenizing
+//
+// Tokenize splits a line like strings.Fields does, but keeps where each
+// token was, for inputs where the columns line up between rows. Columns count
+// runes from 0, so a token's columns can be compared across lines.
+
+// Token is a run of non-separator runes in a line. It covers columns
+// Start up to but not including End.
+type Token struct {
+	Text       string
+	Start, End int
+}
+
+// Tokenize splits line on Unicode whitespace.
+func Tokenize(line string) []Token {
+	return TokenizeFunc(line, unicode.IsSpace)
+}
+
+// TokenizeAny splits line on any of the runes in separators.
+func TokenizeAny(line, separators string) []Token {
+	return TokenizeFunc(line, func(r rune) bool {
+		return strings.ContainsRune(separators, r)
+	})
+}
+
+// TokenizeFunc splits line on runes where isSep is true. Token texts share
+// line's memory, so the returned slice is the only allocation. It's nil if
+// there are no tokens.
+func TokenizeFunc(line string, isSep func(rune) bool) []Token {
+	// Count first so the slice is only allocated once
+	n, inToken := 0, false
+	for _, r := range line {
+		sep := isSep(r)
+		if !sep && !inToken {
+			n++
+		}
+		inToken = !sep
+	}
+	if n == 0 {
+		return nil
+	}
+
+	tokens := make([]Token, 0, n)
+	start, startCol, col := -1, 0, 0
+	for i, r := range line {
+		if isSep(r) {
+			if start >= 0 {
+				tokens = append(tokens, Token{Text: line[start:i], Start: startCol, End: col})
+				start = -1
+			}
+		} else if start < 0 {
+			start, startCol = i, col
+		}
+		col++
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Text: line[start:], Start: startCol, End: col})
+	}
+	return tokens
+}
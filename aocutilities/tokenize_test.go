@@ -0,0 +1,62 @@
+package aocutilites
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []Token
+		want []Token
+	}{
+		{"empty", Tokenize(""), nil},
+		{"only spaces", Tokenize("   "), nil},
+		{"aligned", Tokenize("123 328  51"), []Token{{"123", 0, 3}, {"328", 4, 7}, {"51", 9, 11}}},
+		{"leading and trailing", Tokenize("  *   + "), []Token{{"*", 2, 3}, {"+", 6, 7}}},
+		{"unicode whitespace", Tokenize("a b　c\td"), []Token{{"a", 0, 1}, {"b", 2, 3}, {"c", 4, 5}, {"d", 6, 7}}},
+		{"columns count runes", Tokenize("é ü 7"), []Token{{"é", 0, 1}, {"ü", 2, 3}, {"7", 4, 5}}},
+		{"separators", TokenizeAny("1,2;;3", ",;"), []Token{{"1", 0, 1}, {"2", 2, 3}, {"3", 5, 6}}},
+		{"func", TokenizeFunc("ab12cd3", unicode.IsLetter), []Token{{"12", 2, 4}, {"3", 6, 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeAllocs(t *testing.T) {
+	line := "  6 98  215 314"
+	for name, f := range map[string]func(){
+		"Tokenize":    func() { Tokenize(line) },
+		"TokenizeAny": func() { TokenizeAny(line, " ,") },
+	} {
+		if n := testing.AllocsPerRun(100, f); n != 1 {
+			t.Errorf("%s allocated %v times per line, want 1", name, n)
+		}
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{" \t ", nil},
+		{"a b\tc", []string{"a", "b", "c"}},
+		{"  12  +  ", []string{"12", "+"}},
+	}
+
+	for _, tt := range tests {
+		if got := SplitWords(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitWords(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
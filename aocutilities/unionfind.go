@@ -0,0 +1,218 @@
+package aocutilites
+
+// UnionFind
+//
+// A disjoint-set over the elements 0..n-1, stored in slices. Find compresses
+// paths iteratively and Union joins the smaller set onto the larger, so both
+// are close to O(1).
+
+type UnionFind struct {
+	parent []int
+	size   []int
+	count  int
+}
+
+func NewUnionFind(n int) *UnionFind {
+	uf := &UnionFind{
+		parent: make([]int, n),
+		size:   make([]int, n),
+		count:  n,
+	}
+	for i := range n {
+		uf.parent[i] = i
+		uf.size[i] = 1
+	}
+	return uf
+}
+
+// Add appends a new element in a set of its own and returns it.
+func (uf *UnionFind) Add() int {
+	x := len(uf.parent)
+	uf.parent = append(uf.parent, x)
+	uf.size = append(uf.size, 1)
+	uf.count++
+	return x
+}
+
+// Find returns the root of the set containing x.
+func (uf *UnionFind) Find(x int) int {
+	root := x
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+
+	// Path compression: point everything on the way straight at the root
+	for uf.parent[x] != root {
+		next := uf.parent[x]
+		uf.parent[x] = root
+		x = next
+	}
+
+	return root
+}
+
+// Union merges the sets containing x and y. It returns false if they were
+// already in the same set.
+func (uf *UnionFind) Union(x, y int) bool {
+	rootX := uf.Find(x)
+	rootY := uf.Find(y)
+
+	if rootX == rootY {
+		return false
+	}
+
+	// Union by size
+	if uf.size[rootX] < uf.size[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	uf.parent[rootY] = rootX
+	uf.size[rootX] += uf.size[rootY]
+	uf.count--
+
+	return true
+}
+
+func (uf *UnionFind) Connected(x, y int) bool {
+	return uf.Find(x) == uf.Find(y)
+}
+
+// Size returns the size of the set containing x.
+func (uf *UnionFind) Size(x int) int {
+	return uf.size[uf.Find(x)]
+}
+
+// Count returns the number of separate sets.
+func (uf *UnionFind) Count() int {
+	return uf.count
+}
+
+// Len returns the number of elements.
+func (uf *UnionFind) Len() int {
+	return len(uf.parent)
+}
+
+// Roots returns the root of every set.
+func (uf *UnionFind) Roots() []int {
+	roots := make([]int, 0, uf.count)
+	for i, p := range uf.parent {
+		if p == i {
+			roots = append(roots, i)
+		}
+	}
+	return roots
+}
+
+// Sizes returns the size of every set, in the same order as Roots. Only the
+// roots' sizes are read, so nothing is rescanned.
+func (uf *UnionFind) Sizes() []int {
+	sizes := make([]int, 0, uf.count)
+	for i, p := range uf.parent {
+		if p == i {
+			sizes = append(sizes, uf.size[i])
+		}
+	}
+	return sizes
+}
+
+// Members returns every element in the same set as x.
+func (uf *UnionFind) Members(x int) []int {
+	root := uf.Find(x)
+	members := make([]int, 0, uf.size[root])
+	for i := range uf.parent {
+		if uf.Find(i) == root {
+			members = append(members, i)
+		}
+	}
+	return members
+}
+
+// KeyedUnionFind is a UnionFind over arbitrary comparable values. Add and
+// Union add values the first time they are seen. The queries never do: an
+// unseen value has no representative, isn't connected to anything, and is
+// its own only member.
+type KeyedUnionFind[K comparable] struct {
+	uf    UnionFind
+	index map[K]int
+	keys  []K
+}
+
+func NewKeyedUnionFind[K comparable]() *KeyedUnionFind[K] {
+	return &KeyedUnionFind[K]{index: map[K]int{}}
+}
+
+func (k *KeyedUnionFind[K]) id(key K) int {
+	if i, ok := k.index[key]; ok {
+		return i
+	}
+	i := k.uf.Add()
+	k.index[key] = i
+	k.keys = append(k.keys, key)
+	return i
+}
+
+// Add puts key in a set of its own if it hasn't been seen before.
+func (k *KeyedUnionFind[K]) Add(key K) {
+	k.id(key)
+}
+
+// Find returns the representative of the set containing key, or false if
+// key hasn't been added.
+func (k *KeyedUnionFind[K]) Find(key K) (K, bool) {
+	i, ok := k.index[key]
+	if !ok {
+		var zero K
+		return zero, false
+	}
+	return k.keys[k.uf.Find(i)], true
+}
+
+func (k *KeyedUnionFind[K]) Union(a, b K) bool {
+	return k.uf.Union(k.id(a), k.id(b))
+}
+
+func (k *KeyedUnionFind[K]) Connected(a, b K) bool {
+	i, okA := k.index[a]
+	j, okB := k.index[b]
+	return okA && okB && k.uf.Connected(i, j)
+}
+
+func (k *KeyedUnionFind[K]) Size(key K) int {
+	i, ok := k.index[key]
+	if !ok {
+		return 1
+	}
+	return k.uf.Size(i)
+}
+
+func (k *KeyedUnionFind[K]) Count() int {
+	return k.uf.Count()
+}
+
+func (k *KeyedUnionFind[K]) Len() int {
+	return k.uf.Len()
+}
+
+// Roots returns the representative of every set.
+func (k *KeyedUnionFind[K]) Roots() []K {
+	roots := []K{}
+	for _, i := range k.uf.Roots() {
+		roots = append(roots, k.keys[i])
+	}
+	return roots
+}
+
+func (k *KeyedUnionFind[K]) Sizes() []int {
+	return k.uf.Sizes()
+}
+
+func (k *KeyedUnionFind[K]) Members(key K) []K {
+	i, ok := k.index[key]
+	if !ok {
+		return []K{key}
+	}
+	members := []K{}
+	for _, i := range k.uf.Members(i) {
+		members = append(members, k.keys[i])
+	}
+	return members
+}
@@ -0,0 +1,207 @@
+package aocutilites
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := NewUnionFind(8)
+
+	if uf.Count() != 8 || uf.Len() != 8 {
+		t.Fatalf("Count() = %d, Len() = %d, want 8, 8", uf.Count(), uf.Len())
+	}
+
+	unions := [][2]int{{0, 1}, {1, 2}, {3, 4}, {5, 6}, {6, 3}}
+	for _, u := range unions {
+		if !uf.Union(u[0], u[1]) {
+			t.Fatalf("Union(%d, %d) = false, want true", u[0], u[1])
+		}
+	}
+	if uf.Union(2, 0) {
+		t.Fatal("Union of elements already in one set returned true")
+	}
+
+	if uf.Count() != 3 {
+		t.Fatalf("Count() = %d, want 3", uf.Count())
+	}
+	if !uf.Connected(4, 5) || uf.Connected(2, 3) {
+		t.Fatal("Connected gave the wrong answer")
+	}
+	if uf.Size(6) != 4 || uf.Size(7) != 1 {
+		t.Fatalf("Size(6) = %d, Size(7) = %d, want 4, 1", uf.Size(6), uf.Size(7))
+	}
+
+	sizes := uf.Sizes()
+	slices.Sort(sizes)
+	if want := []int{1, 3, 4}; !slices.Equal(sizes, want) {
+		t.Fatalf("Sizes() = %v, want %v", sizes, want)
+	}
+	if len(uf.Roots()) != 3 {
+		t.Fatalf("Roots() = %v, want 3 roots", uf.Roots())
+	}
+
+	if got := uf.Members(4); !slices.Equal(got, []int{3, 4, 5, 6}) {
+		t.Fatalf("Members(4) = %v", got)
+	}
+
+	x := uf.Add()
+	if x != 8 || uf.Count() != 4 || uf.Size(x) != 1 {
+		t.Fatalf("Add() = %d with Count() = %d", x, uf.Count())
+	}
+}
+
+func TestUnionFindLongChain(t *testing.T) {
+	// A chain this long would blow the stack with a recursive Find
+	n := 1_000_000
+	uf := NewUnionFind(n)
+	for i := 1; i < n; i++ {
+		uf.parent[i] = i - 1
+	}
+	uf.size[0] = n
+	uf.count = 1
+
+	if uf.Find(n-1) != 0 {
+		t.Fatal("Find didn't reach the root")
+	}
+	if uf.parent[n-1] != 0 || uf.parent[n/2] != 0 {
+		t.Fatal("Find didn't compress the path")
+	}
+}
+
+func TestKeyedUnionFind(t *testing.T) {
+	uf := NewKeyedUnionFind[string]()
+
+	uf.Union("a", "b")
+	uf.Union("c", "d")
+	uf.Union("b", "d")
+	uf.Add("e")
+
+	if uf.Count() != 2 || uf.Len() != 5 {
+		t.Fatalf("Count() = %d, Len() = %d, want 2, 5", uf.Count(), uf.Len())
+	}
+	ra, _ := uf.Find("a")
+	rc, _ := uf.Find("c")
+	if ra != rc {
+		t.Fatal("a and c should share a representative")
+	}
+	if uf.Connected("a", "e") {
+		t.Fatal("a and e shouldn't be connected")
+	}
+	if uf.Size("d") != 4 {
+		t.Fatalf("Size(d) = %d, want 4", uf.Size("d"))
+	}
+
+	members := uf.Members("c")
+	slices.Sort(members)
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(members, want) {
+		t.Fatalf("Members(c) = %v, want %v", members, want)
+	}
+
+	roots := uf.Roots()
+	slices.Sort(roots)
+	if len(roots) != 2 || roots[1] != "e" {
+		t.Fatalf("Roots() = %v", roots)
+	}
+
+	// Asking about an unseen key doesn't add it
+	if _, ok := uf.Find("zzz"); ok {
+		t.Fatal("Find(zzz) found a set")
+	}
+	if uf.Connected("a", "zzz") || uf.Connected("zzz", "zzz") {
+		t.Fatal("zzz is connected")
+	}
+	if uf.Size("zzz") != 1 || !slices.Equal(uf.Members("zzz"), []string{"zzz"}) {
+		t.Fatalf("zzz has size %d, members %v", uf.Size("zzz"), uf.Members("zzz"))
+	}
+	if uf.Count() != 2 || uf.Len() != 5 {
+		t.Fatalf("after queries Count() = %d, Len() = %d, want 2, 5", uf.Count(), uf.Len())
+	}
+}
+
+func benchmarkUnions(n int) [][2]int {
+	r := rand.New(rand.NewSource(1))
+	unions := make([][2]int, n)
+	for i := range unions {
+		unions[i] = [2]int{r.Intn(n), r.Intn(n)}
+	}
+	return unions
+}
+
+func BenchmarkUnionFind(b *testing.B) {
+	const n = 100_000
+	unions := benchmarkUnions(n)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		uf := NewUnionFind(n)
+		for _, u := range unions {
+			uf.Union(u[0], u[1])
+		}
+		uf.Sizes()
+	}
+}
+
+// mapUnionFind is the map-backed, recursive version Puzzle8 used to have, kept
+// to compare against.
+type mapUnionFind struct {
+	parent map[int]int
+	size   map[int]int
+}
+
+func (uf *mapUnionFind) find(x int) int {
+	if uf.parent[x] != x {
+		uf.parent[x] = uf.find(uf.parent[x])
+	}
+	return uf.parent[x]
+}
+
+func (uf *mapUnionFind) union(x, y int) {
+	rootX, rootY := uf.find(x), uf.find(y)
+	if rootX == rootY {
+		return
+	}
+	if uf.size[rootX] < uf.size[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	uf.parent[rootY] = rootX
+	uf.size[rootX] += uf.size[rootY]
+}
+
+func BenchmarkMapUnionFind(b *testing.B) {
+	const n = 100_000
+	unions := benchmarkUnions(n)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		uf := &mapUnionFind{parent: map[int]int{}, size: map[int]int{}}
+		for i := range n {
+			uf.parent[i] = i
+			uf.size[i] = 1
+		}
+		for _, u := range unions {
+			uf.union(u[0], u[1])
+		}
+
+		sizes := map[int]int{}
+		for i := range uf.parent {
+			root := uf.find(i)
+			sizes[root] = uf.size[root]
+		}
+	}
+}
+
+func BenchmarkKeyedUnionFind(b *testing.B) {
+	const n = 100_000
+	unions := benchmarkUnions(n)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		uf := NewKeyedUnionFind[int]()
+		for _, u := range unions {
+			uf.Union(u[0], u[1])
+		}
+		uf.Sizes()
+	}
+}
@@ -0,0 +1,140 @@
+package aocutilites
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Column-aligned blocks
+//
+// Some inputs are laid out as fixed-width blocks side by side, separated by
+// columns that are blank on every line:
+//
+//	123 328
+//	 45 64
+//	  6 98
+//	*   +
+//
+// ParseBlocks cuts these apart, padding short lines with spaces. Each block
+// can then be read across (Row) or down (Col). ParseWorksheet also takes the
+// last line as a row of operators, one per block.
+
+// Block is one column-aligned block. It covers columns Start up to but not
+// including End of the input, counting runes from 0. Row i of the block is
+// line i of the input.
+type Block struct {
+	Start, End int
+	// Op is the block's text in the operator row, trimmed. It's only set by
+	// ParseWorksheet.
+	Op    string
+	cells [][]rune
+}
+
+func (b Block) Width() int {
+	return b.End - b.Start
+}
+
+func (b Block) Height() int {
+	return len(b.cells)
+}
+
+// Row returns row i read left to right, padded to the block's width.
+func (b Block) Row(i int) string {
+	return string(b.cells[i])
+}
+
+// Col returns column j read top to bottom.
+func (b Block) Col(j int) string {
+	col := make([]rune, len(b.cells))
+	for i, row := range b.cells {
+		col[i] = row[j]
+	}
+	return string(col)
+}
+
+func (b Block) Rows() []string {
+	rows := make([]string, b.Height())
+	for i := range rows {
+		rows[i] = b.Row(i)
+	}
+	return rows
+}
+
+func (b Block) Cols() []string {
+	cols := make([]string, b.Width())
+	for j := range cols {
+		cols[j] = b.Col(j)
+	}
+	return cols
+}
+
+// ParseBlocks splits lines into blocks at every column that's blank on all
+// of them.
+func ParseBlocks(lines []string) []Block {
+	grid := make([][]rune, len(lines))
+	width := 0
+	for i, line := range lines {
+		grid[i] = []rune(line)
+		width = max(width, len(grid[i]))
+	}
+	for i, row := range grid {
+		for len(row) < width {
+			row = append(row, ' ')
+		}
+		grid[i] = row
+	}
+
+	blank := func(col int) bool {
+		for _, row := range grid {
+			if !unicode.IsSpace(row[col]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	blocks := []Block{}
+	start := -1
+	for col := 0; col <= width; col++ {
+		if col < width && !blank(col) {
+			if start < 0 {
+				start = col
+			}
+			continue
+		}
+		if start >= 0 {
+			b := Block{Start: start, End: col, cells: make([][]rune, len(grid))}
+			for i, row := range grid {
+				b.cells[i] = row[start:col:col]
+			}
+			blocks = append(blocks, b)
+			start = -1
+		}
+	}
+	return blocks
+}
+
+// ParseWorksheet is ParseBlocks where the last non-empty line holds each
+// block's operator. The operator row isn't part of the blocks' rows.
+func ParseWorksheet(lines []string) ([]Block, error) {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) < 2 {
+		return nil, errors.New("worksheet needs at least one row and an operator row")
+	}
+
+	blocks := ParseBlocks(lines)
+	for i, b := range blocks {
+		last := len(b.cells) - 1
+		b.Op = strings.TrimSpace(string(b.cells[last]))
+		b.cells = b.cells[:last]
+		if b.Op == "" {
+			return nil, fmt.Errorf("columns %d-%d have no operator", b.Start+1, b.End)
+		}
+		blocks[i] = b
+	}
+	return blocks, nil
+}
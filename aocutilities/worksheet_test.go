@@ -0,0 +1,83 @@
+package aocutilites
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBlocks(t *testing.T) {
+	// Short lines are padded, and a column only splits blocks if it's blank
+	// on every line
+	blocks := ParseBlocks([]string{
+		"ab  c",
+		" d e",
+		"",
+	})
+
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+	first, second := blocks[0], blocks[1]
+	if first.Start != 0 || first.End != 2 || second.Start != 3 || second.End != 5 {
+		t.Fatalf("blocks cover %d-%d and %d-%d", first.Start, first.End, second.Start, second.End)
+	}
+	if got := first.Rows(); !reflect.DeepEqual(got, []string{"ab", " d", "  "}) {
+		t.Errorf("Rows() = %q", got)
+	}
+	if got := first.Cols(); !reflect.DeepEqual(got, []string{"a  ", "bd "}) {
+		t.Errorf("Cols() = %q", got)
+	}
+	if got := second.Rows(); !reflect.DeepEqual(got, []string{" c", "e ", "  "}) {
+		t.Errorf("Rows() = %q", got)
+	}
+
+	if got := ParseBlocks([]string{"   ", ""}); len(got) != 0 {
+		t.Errorf("blank lines gave %d blocks", len(got))
+	}
+}
+
+func TestParseWorksheet(t *testing.T) {
+	blocks, err := ParseWorksheet([]string{
+		"123 328  51 64 ",
+		" 45 64  387 23 ",
+		"  6 98  215 314",
+		"*   +   *   +  ",
+		"",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ops := []string{}
+	for _, b := range blocks {
+		ops = append(ops, b.Op)
+	}
+	if !reflect.DeepEqual(ops, []string{"*", "+", "*", "+"}) {
+		t.Fatalf("ops = %q", ops)
+	}
+
+	last := blocks[3]
+	if last.Height() != 3 || last.Width() != 3 {
+		t.Fatalf("last block is %dx%d, want 3x3", last.Width(), last.Height())
+	}
+	if got := last.Rows(); !reflect.DeepEqual(got, []string{"64 ", "23 ", "314"}) {
+		t.Errorf("Rows() = %q", got)
+	}
+	if got := last.Cols(); !reflect.DeepEqual(got, []string{"623", "431", "  4"}) {
+		t.Errorf("Cols() = %q", got)
+	}
+}
+
+func TestParseWorksheetErrors(t *testing.T) {
+	tests := map[string][]string{
+		"empty":          {},
+		"only operators": {"*  +", ""},
+		"no operator":    {"12 34", "5  6", "*    "},
+	}
+
+	for name, lines := range tests {
+		if _, err := ParseWorksheet(lines); err == nil {
+			t.Errorf("%s: ParseWorksheet didn't fail", name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// AnswerKey identifies a known answer. Answers depend on the input, so the
+// input is part of the key.
+type AnswerKey struct {
+	Day       int    `json:"day"`
+	Part      int    `json:"part"`
+	InputHash string `json:"input_sha256"`
+}
+
+type answerEntry struct {
+	AnswerKey
+	Answer int `json:"answer"`
+}
+
+// AnswerStore is a local record of answers that are known to be correct.
+type AnswerStore struct {
+	path    string
+	answers map[AnswerKey]int
+}
+
+// LoadAnswers reads the store at path. A missing file is an empty store.
+func LoadAnswers(path string) (*AnswerStore, error) {
+	store := &AnswerStore{path: path, answers: map[AnswerKey]int{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []answerEntry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		store.answers[e.AnswerKey] = e.Answer
+	}
+
+	return store, nil
+}
+
+func (s *AnswerStore) Lookup(key AnswerKey) (int, bool) {
+	answer, ok := s.answers[key]
+	return answer, ok
+}
+
+func (s *AnswerStore) Record(key AnswerKey, answer int) {
+	s.answers[key] = answer
+}
+
+// Save writes the store back in a stable order so it diffs cleanly.
+func (s *AnswerStore) Save() error {
+	entries := []answerEntry{}
+	for key, answer := range s.answers {
+		entries = append(entries, answerEntry{AnswerKey: key, Answer: answer})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Part != b.Part {
+			return a.Part < b.Part
+		}
+		return a.InputHash < b.InputHash
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0o644)
+}
+
+func hashInput(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnswerStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	store, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+
+	// Recorded out of order; Save sorts by day, part, then input
+	keys := []AnswerKey{
+		{Day: 10, Part: 1, InputHash: "b"},
+		{Day: 2, Part: 2, InputHash: "a"},
+		{Day: 2, Part: 1, InputHash: "b"},
+		{Day: 2, Part: 1, InputHash: "a"},
+	}
+	for i, key := range keys {
+		store.Record(key, i)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+  {
+    "day": 2,
+    "part": 1,
+    "input_sha256": "a",
+    "answer": 3
+  },
+  {
+    "day": 2,
+    "part": 1,
+    "input_sha256": "b",
+    "answer": 2
+  },
+  {
+    "day": 2,
+    "part": 2,
+    "input_sha256": "a",
+    "answer": 1
+  },
+  {
+    "day": 10,
+    "part": 1,
+    "input_sha256": "b",
+    "answer": 0
+  }
+]
+`
+	if string(data) != want {
+		t.Errorf("saved store:\n%s\nwant:\n%s", data, want)
+	}
+
+	loaded, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, key := range keys {
+		if got, ok := loaded.Lookup(key); !ok || got != i {
+			t.Errorf("Lookup(%+v) = %d, %v; want %d", key, got, ok, i)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+// BenchResult is the timing of one stage (parse, part1 or part2) of a day.
+type BenchResult struct {
+	Day         int    `json:"day"`
+	Stage       string `json:"stage"`
+	Iterations  int    `json:"iterations"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	MinNs       int64  `json:"min_ns"`
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	BytesPerOp  uint64 `json:"bytes_per_op"`
+	InputHash   string `json:"input_sha256"`
+}
+
+// BenchReport is what -json writes and -compare reads back.
+type BenchReport struct {
+	Time      time.Time     `json:"time"`
+	GoVersion string        `json:"go_version"`
+	GOOS      string        `json:"goos"`
+	GOARCH    string        `json:"goarch"`
+	Results   []BenchResult `json:"results"`
+}
+
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	inputs := addInputFlags(flags)
+	iterations := flags.Int("n", 10, "iterations of each stage")
+	jsonFile := flags.String("json", "", "also write the report as JSON to this file")
+	compareFile := flags.String("compare", "", "JSON report from an earlier run to compare against")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("bench expects at most one day or \"all\"")
+	}
+	if *iterations < 1 {
+		return fmt.Errorf("-n must be at least 1")
+	}
+
+	dayArg := "all"
+	if len(positional) == 1 {
+		dayArg = positional[0]
+	}
+	days, err := selectDays(dayArg)
+	if err != nil {
+		return err
+	}
+	if err := inputs.check(days); err != nil {
+		return err
+	}
+
+	var previous *BenchReport
+	if *compareFile != "" {
+		previous, err = loadBenchReport(*compareFile)
+		if err != nil {
+			return fmt.Errorf("loading %s: %w", *compareFile, err)
+		}
+	}
+
+	report := BenchReport{
+		Time:      time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+	}
+
+	for _, day := range days {
+		puzzle, data, err := inputs.load(day)
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Skipping day %d: no input\n", day)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		results, err := benchDay(puzzle, data, *iterations)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+		report.Results = append(report.Results, results...)
+	}
+
+	printBenchReport(report, previous)
+
+	if *jsonFile != "" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*jsonFile, append(out, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// benchDay times parsing and each part separately. The parts all share one
+// parsed input, which is fine because parts must not modify it.
+func benchDay(puzzle aocutilites.Puzzle, data []byte, iterations int) ([]BenchResult, error) {
+	inputHash := hashInput(data)
+
+	var input any
+	parse := func() error {
+		var err error
+		input, err = puzzle.Parse(bytes.NewReader(data))
+		return err
+	}
+
+	stages := []struct {
+		name string
+		run  func() error
+	}{
+		{"parse", parse},
+		{"part1", func() error { _, err := puzzle.Part1(input); return err }},
+		{"part2", func() error { _, err := puzzle.Part2(input); return err }},
+	}
+
+	results := []BenchResult{}
+	for _, stage := range stages {
+		result, err := measure(stage.run, iterations)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", stage.name, err)
+		}
+		result.Day = puzzle.Day
+		result.Stage = stage.name
+		result.InputHash = inputHash
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func measure(run func() error, iterations int) (BenchResult, error) {
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	var total time.Duration
+	minimum := time.Duration(-1)
+	for i := 0; i < iterations; i++ {
+		start := time.Now()
+		if err := run(); err != nil {
+			return BenchResult{}, err
+		}
+		elapsed := time.Since(start)
+
+		total += elapsed
+		if minimum < 0 || elapsed < minimum {
+			minimum = elapsed
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+
+	n := uint64(iterations)
+	return BenchResult{
+		Iterations:  iterations,
+		NsPerOp:     total.Nanoseconds() / int64(iterations),
+		MinNs:       minimum.Nanoseconds(),
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / n,
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / n,
+	}, nil
+}
+
+func loadBenchReport(path string) (*BenchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BenchReport{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func printBenchReport(report BenchReport, previous *BenchReport) {
+	type benchKey struct {
+		day   int
+		stage string
+	}
+	old := map[benchKey]BenchResult{}
+	if previous != nil {
+		for _, r := range previous.Results {
+			old[benchKey{r.Day, r.Stage}] = r
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := "Day\tStage\tIterations\ttime/op\tmin\tallocs/op\tbytes/op\t"
+	if previous != nil {
+		header += "Δ time\tΔ allocs\t"
+	}
+	fmt.Fprintln(w, header)
+
+	for _, r := range report.Results {
+		line := fmt.Sprintf("%d\t%s\t%d\t%s\t%s\t%d\t%d\t",
+			r.Day, r.Stage, r.Iterations, time.Duration(r.NsPerOp), time.Duration(r.MinNs), r.AllocsPerOp, r.BytesPerOp)
+
+		if previous != nil {
+			prev, ok := old[benchKey{r.Day, r.Stage}]
+			switch {
+			case !ok:
+				line += "new\t\t"
+			case prev.InputHash != r.InputHash:
+				line += "input changed\t\t"
+			default:
+				line += fmt.Sprintf("%s\t%s\t", percentChange(prev.NsPerOp, r.NsPerOp),
+					percentChange(int64(prev.AllocsPerOp), int64(r.AllocsPerOp)))
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	w.Flush()
+}
+
+func percentChange(before, after int64) string {
+	if before == 0 {
+		if after == 0 {
+			return "~"
+		}
+		return "+inf"
+	}
+	return fmt.Sprintf("%+.1f%%", 100*float64(after-before)/float64(before))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	calls := 0
+	result, err := measure(func() error { calls++; return nil }, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 5 || result.Iterations != 5 {
+		t.Errorf("ran %d times, Iterations = %d; want 5", calls, result.Iterations)
+	}
+	if result.MinNs > result.NsPerOp {
+		t.Errorf("min %dns is more than the mean %dns", result.MinNs, result.NsPerOp)
+	}
+
+	calls = 0
+	failure := errors.New("parse failed")
+	if _, err := measure(func() error { calls++; return failure }, 5); !errors.Is(err, failure) || calls != 1 {
+		t.Errorf("error = %v after %d calls, want the first call's error", err, calls)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	tests := []struct {
+		before, after int64
+		want          string
+	}{
+		{100, 150, "+50.0%"},
+		{200, 100, "-50.0%"},
+		{100, 100, "+0.0%"},
+		{0, 0, "~"},
+		{0, 10, "+inf"},
+	}
+
+	for _, tt := range tests {
+		if got := percentChange(tt.before, tt.after); got != tt.want {
+			t.Errorf("percentChange(%d, %d) = %q, want %q", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestBenchReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.json")
+	captureStdout(t, func() error {
+		return benchCommand([]string{"1", "-input", "example", "-n", "2", "-json", path})
+	})
+
+	report, err := loadBenchReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stages := []string{}
+	hash := hashInput([]byte(mustInput(t, 1, "example")))
+	for _, r := range report.Results {
+		stages = append(stages, r.Stage)
+		if r.Day != 1 || r.Iterations != 2 || r.InputHash != hash {
+			t.Errorf("result %+v", r)
+		}
+	}
+	if !reflect.DeepEqual(stages, []string{"parse", "part1", "part2"}) {
+		t.Fatalf("stages = %v", stages)
+	}
+
+	// Compare against a run where parse had a different input and part2
+	// wasn't measured
+	previous := *report
+	previous.Results = []BenchResult{report.Results[0], report.Results[1]}
+	previous.Results[0].InputHash = "older"
+	previous.Results[1].NsPerOp = report.Results[1].NsPerOp + 1000
+
+	out := captureStdout(t, func() error {
+		printBenchReport(*report, &previous)
+		return nil
+	})
+
+	rows := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		for _, stage := range stages {
+			if strings.Contains(line, stage) {
+				rows[stage] = line
+			}
+		}
+	}
+	if !strings.Contains(rows["parse"], "input changed") {
+		t.Errorf("parse row = %q, want input changed", rows["parse"])
+	}
+	if !strings.Contains(rows["part1"], "%") {
+		t.Errorf("part1 row = %q, want a percentage change", rows["part1"])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(rows["part2"]), "new") {
+		t.Errorf("part2 row = %q, want new", rows["part2"])
+	}
+}
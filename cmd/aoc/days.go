@@ -0,0 +1,13 @@
+package main
+
+// Each day registers itself with aocutilities when its package is imported.
+import (
+	_ "AOC2025/Puzzle1"
+	_ "AOC2025/Puzzle2"
+	_ "AOC2025/Puzzle3"
+	_ "AOC2025/Puzzle4"
+	_ "AOC2025/Puzzle5"
+	_ "AOC2025/Puzzle6"
+	_ "AOC2025/Puzzle7"
+	_ "AOC2025/Puzzle8"
+)
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"AOC2025/aocutilities/client"
+)
+
+// fetchCommand downloads the input for each day that doesn't have one yet.
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	root := flags.String("root", ".", "repository root containing the PuzzleN directories")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("fetch expects exactly one day or \"all\"")
+	}
+
+	days, err := selectDays(positional[0])
+	if err != nil {
+		return err
+	}
+
+	c := client.New(*root)
+	for _, day := range days {
+		data, err := c.Input(context.Background(), day)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Day %d: %s (%d bytes)\n", day, c.InputPath(day), len(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+// inputFlags is the -root/-input flag layer shared by the commands that read
+// puzzle input.
+type inputFlags struct {
+	root  *string
+	input *string
+}
+
+func addInputFlags(flags *flag.FlagSet) *inputFlags {
+	return &inputFlags{
+		root: flags.String("root", ".", "repository root containing the PuzzleN directories"),
+		input: flags.String("input", "real",
+			"input to use: \"real\" (PuzzleN/input.txt), a set the day embeds such as \"example\", \"-\" for stdin, or a file path"),
+	}
+}
+
+// check rejects combinations that can't work before any day is run.
+func (f *inputFlags) check(days []int) error {
+	if *f.input == "-" && len(days) != 1 {
+		return errors.New("-input - reads stdin, so it needs a single day")
+	}
+	return nil
+}
+
+// load returns the selected input for a day, and the day's puzzle set up to
+// solve it.
+func (f *inputFlags) load(day int) (aocutilites.Puzzle, []byte, error) {
+	puzzle, _ := aocutilites.Lookup(day)
+
+	switch name := *f.input; name {
+	case "", "real":
+		data, err := os.ReadFile(inputPath(*f.root, day))
+		return puzzle, data, err
+	case "-":
+		data, err := io.ReadAll(os.Stdin)
+		return puzzle, data, err
+	default:
+		if data, ok := puzzle.Inputs[name]; ok {
+			return puzzle.ForInput(name), []byte(data), nil
+		}
+
+		data, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) && filepath.Base(name) == name {
+			return puzzle, nil, fmt.Errorf("day %d has no %q input and there is no file of that name", day, name)
+		}
+		return puzzle, data, err
+	}
+}
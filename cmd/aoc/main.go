@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+const usage = `Usage: aoc <command> [arguments]
+
+Commands:
+  run <day|all> [-part 1|2] [-input set|file|-] [-root dir] [-visualise] [-overflow wrap|check|big]
+  verify [day|all] [-input set|file|-] [-root dir] [-answers file] [-record]
+  bench [day|all] [-n iterations] [-json file] [-compare file] [-input set|file|-] [-root dir]
+  fetch <day|all> [-root dir]
+  new <day> [-root dir]
+  submit <day> <part> [answer] [-root dir] [-answers file]
+
+-input picks the puzzle input: "real" (the default) is PuzzleN/input.txt,
+"example" is the example each day embeds, "-" reads stdin and anything else
+is a file path.
+
+-overflow changes how days that use checked ints handle overflow: "wrap"
+(the default) doesn't check, "check" reports the first overflow and where
+in the input it happened, and "big" also works out the exact answer with
+math/big.
+
+fetch, submit and new read the session token from $AOC_SESSION or the file named by
+$AOC_SESSION_FILE (default <config dir>/aoc/session).
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "verify":
+		err = verifyCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "new":
+		err = newCommand(os.Args[2:])
+	case "fetch":
+		err = fetchCommand(os.Args[2:])
+	case "submit":
+		err = submitCommand(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	part := fs.Int("part", 0, "only run this part (1 or 2)")
+	inputs := addInputFlags(fs)
+	visualise := fs.Bool("visualise", false, "start the day's visualisation after running, if it has one")
+	overflow := fs.String("overflow", "wrap", "overflow handling for checked ints: wrap, check or big")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("run expects exactly one day or \"all\"")
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return fmt.Errorf("invalid part %d", *part)
+	}
+	mode, err := aocutilites.ParseOverflowMode(*overflow)
+	if err != nil {
+		return err
+	}
+	aocutilites.SetOverflowMode(mode)
+
+	days, err := selectDays(positional[0])
+	if err != nil {
+		return err
+	}
+	if err := inputs.check(days); err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		puzzle, data, err := inputs.load(day)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+
+		input, err := puzzle.Parse(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+
+		for _, p := range selectParts(*part) {
+			solve, _ := puzzle.Part(p)
+
+			result, err := solve(input)
+			if err != nil {
+				return fmt.Errorf("day %d part %d: %w", day, p, err)
+			}
+			fmt.Printf("Day %d Part %d: %d\n", day, p, result)
+		}
+
+		if *visualise && puzzle.Visualise != nil {
+			visPart := *part
+			if visPart == 0 {
+				visPart = 2
+			}
+			if err := puzzle.Visualise(input, visPart); err != nil {
+				return fmt.Errorf("day %d visualisation: %w", day, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectDays turns a day argument into the registered days to run.
+func selectDays(arg string) ([]int, error) {
+	if arg == "all" {
+		return aocutilites.Days(), nil
+	}
+
+	day, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day %q", arg)
+	}
+	if _, ok := aocutilites.Lookup(day); !ok {
+		return nil, fmt.Errorf("day %d is not registered", day)
+	}
+	return []int{day}, nil
+}
+
+// selectParts turns the -part flag into the parts to run, 0 meaning both.
+func selectParts(part int) []int {
+	if part == 0 {
+		return []int{1, 2}
+	}
+	return []int{part}
+}
+
+func inputPath(root string, day int) string {
+	return filepath.Join(root, fmt.Sprintf("Puzzle%d", day), "input.txt")
+}
+
+// parseArgs lets flags appear before or after the positional arguments, so
+// both "aoc run -part 2 3" and "aoc run 3 --part 2" work.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
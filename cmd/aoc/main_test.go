@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	aocutilites "AOC2025/aocutilities"
+)
+
+// captureStdout returns what run prints while it runs.
+func captureStdout(t *testing.T, run func() error) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+
+	runErr := run()
+	w.Close()
+	printed := <-out
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	return printed
+}
+
+func TestRunExampleInput(t *testing.T) {
+	tests := []struct {
+		day  string
+		want string
+	}{
+		{"1", "Day 1 Part 1: 3\nDay 1 Part 2: 6\n"},
+		// The example has its own connection count
+		{"8", "Day 8 Part 1: 40\nDay 8 Part 2: 25272\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run("day "+tt.day, func(t *testing.T) {
+			got := captureStdout(t, func() error {
+				return runCommand([]string{tt.day, "-input", "example"})
+			})
+			if got != tt.want {
+				t.Errorf("run %s -input example printed\n%s\nwant\n%s", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunInputFile(t *testing.T) {
+	// A file, unlike the named example, runs with the day's real settings
+	got := captureStdout(t, func() error {
+		return runCommand([]string{"8", "-input", "../../Puzzle8/testdata/example.txt", "-part", "1"})
+	})
+	if !strings.HasPrefix(got, "Day 8 Part 1: 20\n") {
+		t.Errorf("run 8 on the example file printed %q", got)
+	}
+}
+
+// mustInput returns a named input that a day embeds.
+func mustInput(t *testing.T, day int, name string) string {
+	t.Helper()
+	puzzle, _ := aocutilites.Lookup(day)
+	data, ok := puzzle.Inputs[name]
+	if !ok {
+		t.Fatalf("day %d has no %q input", day, name)
+	}
+	return data
+}
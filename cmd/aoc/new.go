@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	aocutilites "AOC2025/aocutilities"
+	"AOC2025/aocutilities/client"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// newCommand scaffolds a PuzzleN package and registers it with the runner.
+// If a session token is available it also downloads the input and example.
+func newCommand(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	root := flags.String("root", ".", "repository root containing the PuzzleN directories")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("new expects exactly one day")
+	}
+
+	day, err := strconv.Atoi(positional[0])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+	if _, ok := aocutilites.Lookup(day); ok {
+		return fmt.Errorf("day %d is already registered", day)
+	}
+
+	dayDir := filepath.Join(*root, fmt.Sprintf("Puzzle%d", day))
+	if _, err := os.Stat(dayDir); err == nil {
+		return fmt.Errorf("%s already exists", dayDir)
+	}
+
+	c := client.New(*root)
+	example := ""
+	if c.Session != "" {
+		example, err = c.Example(context.Background(), day)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Couldn't fetch the example: %v\n", err)
+		}
+	}
+
+	data := struct{ Day int }{day}
+	files := []struct {
+		template string
+		path     string
+	}{
+		{"main.go.tmpl", filepath.Join(dayDir, "main.go")},
+		{"main_test.go.tmpl", filepath.Join(dayDir, "main_test.go")},
+	}
+
+	if err := os.MkdirAll(filepath.Join(dayDir, "testdata"), 0o755); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, f.template, data); err != nil {
+			return err
+		}
+		if err := os.WriteFile(f.path, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("Created %s\n", f.path)
+	}
+
+	examplePath := filepath.Join(dayDir, "testdata", "example.txt")
+	if err := os.WriteFile(examplePath, []byte(example), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Created %s\n", examplePath)
+
+	daysFile := filepath.Join(*root, "cmd", "aoc", "days.go")
+	if err := registerDay(daysFile, day); err != nil {
+		return fmt.Errorf("registering day %d in %s: %w", day, daysFile, err)
+	}
+	fmt.Printf("Registered day %d in %s\n", day, daysFile)
+
+	if c.Session != "" {
+		if _, err := c.Input(context.Background(), day); err != nil {
+			return err
+		}
+		fmt.Printf("Downloaded %s\n", c.InputPath(day))
+	}
+
+	return nil
+}
+
+// registerDay adds the day's package to the blank imports in days.go.
+func registerDay(daysFile string, day int) error {
+	src, err := os.ReadFile(daysFile)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, daysFile, src, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("AOC2025/Puzzle%d", day)
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			return nil
+		}
+	}
+
+	var block *ast.GenDecl
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Rparen.IsValid() {
+			block = gen
+		}
+	}
+	if block == nil {
+		return errors.New("import block not found")
+	}
+
+	end := fset.Position(block.Rparen).Offset
+	text := string(src[:end]) + fmt.Sprintf("\t_ %q\n", path) + string(src[end:])
+
+	// format.Source also sorts the import block
+	formatted, err := format.Source([]byte(text))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(daysFile, formatted, 0o644)
+}
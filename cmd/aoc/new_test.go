@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegisterDay(t *testing.T) {
+	const days = `package main
+
+// Each day registers itself with aocutilities when its package is imported.
+import (
+	_ "AOC2025/Puzzle1"
+	_ "AOC2025/Puzzle2"
+)
+
+// A later closing paren mustn't be mistaken for the import block's
+var _ = len("x")
+`
+	const want = `package main
+
+// Each day registers itself with aocutilities when its package is imported.
+import (
+	_ "AOC2025/Puzzle1"
+	_ "AOC2025/Puzzle10"
+	_ "AOC2025/Puzzle2"
+)
+
+// A later closing paren mustn't be mistaken for the import block's
+var _ = len("x")
+`
+
+	path := filepath.Join(t.TempDir(), "days.go")
+	if err := os.WriteFile(path, []byte(days), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Registering twice, or a day that's already there, changes nothing
+	for _, day := range []int{10, 10, 2} {
+		if err := registerDay(path, day); err != nil {
+			t.Fatalf("registerDay(%d): %v", day, err)
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("days.go is now:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRegisterDayNoImportBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "days.go")
+	if err := os.WriteFile(path, []byte("package main\n\nimport _ \"AOC2025/Puzzle1\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := registerDay(path, 2); err == nil {
+		t.Error("registerDay() didn't fail without an import block")
+	}
+}
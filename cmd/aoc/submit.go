@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	aocutilites "AOC2025/aocutilities"
+	"AOC2025/aocutilities/client"
+)
+
+// submitCommand posts an answer for a day and part. Without an explicit
+// answer it runs the solver on the day's input and submits that.
+func submitCommand(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	root := flags.String("root", ".", "repository root containing the PuzzleN directories")
+	answersFile := flags.String("answers", "", "answer store to record correct answers in (default <root>/answers.json)")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 || len(positional) > 3 {
+		return fmt.Errorf("submit expects a day, a part and optionally an answer")
+	}
+
+	days, err := selectDays(positional[0])
+	if err != nil || len(days) != 1 {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+	day := days[0]
+
+	part, err := strconv.Atoi(positional[1])
+	if err != nil || (part != 1 && part != 2) {
+		return fmt.Errorf("invalid part %q", positional[1])
+	}
+
+	c := client.New(*root)
+	data, err := c.Input(context.Background(), day)
+	if err != nil {
+		return err
+	}
+
+	var answer int
+	if len(positional) == 3 {
+		answer, err = strconv.Atoi(positional[2])
+		if err != nil {
+			return fmt.Errorf("invalid answer %q", positional[2])
+		}
+	} else {
+		answer, err = solvePart(day, part, data)
+		if err != nil {
+			return fmt.Errorf("day %d part %d: %w", day, part, err)
+		}
+	}
+
+	fmt.Printf("Submitting day %d part %d: %d\n", day, part, answer)
+	result, err := c.Submit(context.Background(), day, part, strconv.Itoa(answer))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", result.Outcome, result.Message)
+	if result.Wait > 0 {
+		fmt.Printf("Wait %s before submitting again\n", result.Wait)
+	}
+
+	switch result.Outcome {
+	case client.Correct:
+		if *answersFile == "" {
+			*answersFile = filepath.Join(*root, "answers.json")
+		}
+		store, err := LoadAnswers(*answersFile)
+		if err != nil {
+			return fmt.Errorf("loading answers: %w", err)
+		}
+		store.Record(AnswerKey{Day: day, Part: part, InputHash: hashInput(data)}, answer)
+		return store.Save()
+	case client.AlreadySolved:
+		return nil
+	}
+
+	return fmt.Errorf("answer not accepted")
+}
+
+func solvePart(day, part int, data []byte) (int, error) {
+	puzzle, _ := aocutilites.Lookup(day)
+
+	input, err := puzzle.Parse(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+
+	solve, _ := puzzle.Part(part)
+	return solve(input)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// verifyCommand re-runs the registered solvers and compares each part with
+// the answer store, so refactoring a solved day can't silently change it.
+func verifyCommand(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	inputs := addInputFlags(flags)
+	answersFile := flags.String("answers", "", "answer store (default <root>/answers.json)")
+	record := flags.Bool("record", false, "store the current answer for any part that is unknown")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("verify expects at most one day or \"all\"")
+	}
+
+	dayArg := "all"
+	if len(positional) == 1 {
+		dayArg = positional[0]
+	}
+	days, err := selectDays(dayArg)
+	if err != nil {
+		return err
+	}
+	if err := inputs.check(days); err != nil {
+		return err
+	}
+
+	if *answersFile == "" {
+		*answersFile = filepath.Join(*inputs.root, "answers.json")
+	}
+	store, err := LoadAnswers(*answersFile)
+	if err != nil {
+		return fmt.Errorf("loading answers: %w", err)
+	}
+
+	failures := 0
+	recorded := 0
+
+	for _, day := range days {
+		puzzle, data, err := inputs.load(day)
+		if errors.Is(err, fs.ErrNotExist) {
+			for _, part := range selectParts(0) {
+				fmt.Printf("Day %d Part %d: UNKNOWN  no input\n", day, part)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		inputHash := hashInput(data)
+
+		input, err := puzzle.Parse(bytes.NewReader(data))
+		if err != nil {
+			for _, part := range selectParts(0) {
+				fmt.Printf("Day %d Part %d: FAIL     parse error: %v\n", day, part, err)
+				failures++
+			}
+			continue
+		}
+
+		for _, part := range selectParts(0) {
+			solve, _ := puzzle.Part(part)
+			key := AnswerKey{Day: day, Part: part, InputHash: inputHash}
+			want, known := store.Lookup(key)
+
+			got, err := solve(input)
+			switch {
+			case err != nil:
+				fmt.Printf("Day %d Part %d: FAIL     error: %v\n", day, part, err)
+				failures++
+			case !known && *record:
+				store.Record(key, got)
+				recorded++
+				fmt.Printf("Day %d Part %d: RECORDED %d\n", day, part, got)
+			case !known:
+				fmt.Printf("Day %d Part %d: UNKNOWN  %d\n", day, part, got)
+			case got != want:
+				fmt.Printf("Day %d Part %d: FAIL     got %d, want %d\n", day, part, got, want)
+				failures++
+			default:
+				fmt.Printf("Day %d Part %d: PASS     %d\n", day, part, got)
+			}
+		}
+	}
+
+	if recorded > 0 {
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("saving answers: %w", err)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d part(s) failed verification", failures)
+	}
+	return nil
+}
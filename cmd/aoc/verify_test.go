@@ -0,0 +1,56 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	answers := filepath.Join(t.TempDir(), "answers.json")
+	verify := func(args ...string) (string, error) {
+		var err error
+		out := captureStdout(t, func() error {
+			err = verifyCommand(append([]string{"1", "-input", "example", "-answers", answers}, args...))
+			return nil
+		})
+		return out, err
+	}
+
+	out, err := verify()
+	if err != nil || out != "Day 1 Part 1: UNKNOWN  3\nDay 1 Part 2: UNKNOWN  6\n" {
+		t.Fatalf("empty store: %v\n%s", err, out)
+	}
+
+	out, err = verify("-record")
+	if err != nil || out != "Day 1 Part 1: RECORDED 3\nDay 1 Part 2: RECORDED 6\n" {
+		t.Fatalf("-record: %v\n%s", err, out)
+	}
+
+	out, err = verify()
+	if err != nil || out != "Day 1 Part 1: PASS     3\nDay 1 Part 2: PASS     6\n" {
+		t.Fatalf("after recording: %v\n%s", err, out)
+	}
+
+	// A changed answer must fail, and -record mustn't paper over it
+	store, err := LoadAnswers(answers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := AnswerKey{Day: 1, Part: 2, InputHash: hashInput([]byte(mustInput(t, 1, "example")))}
+	if _, ok := store.Lookup(key); !ok {
+		t.Fatalf("no answer recorded for %+v", key)
+	}
+	store.Record(key, 7)
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = verify("-record")
+	if err == nil {
+		t.Fatal("verify didn't fail on a changed answer")
+	}
+	if !strings.Contains(out, "Day 1 Part 1: PASS     3\n") || !strings.Contains(out, "Day 1 Part 2: FAIL     got 6, want 7\n") {
+		t.Fatalf("changed answer:\n%s", out)
+	}
+}